@@ -16,161 +16,122 @@ type child struct {
 	isMouseLeftButtonHandler bool
 	isMouseEntered           bool
 	handledTouchID           ebiten.TouchID
-	swipe
 }
 
-type swipe struct {
-	downX, downY int
-	upX, upY     int
-	downTime     time.Time
-	upTime       time.Time
-	swipeDir     SwipeDirection
-	swipeTouchID ebiten.TouchID
-}
-
-func (c *child) HandleJustPressedTouchID(
-	frame *image.Rectangle, touchID ebiten.TouchID, x, y int) bool {
-	var result = false
-	if c.checkButtonHandlerStart(frame, touchID, x, y) {
-		result = true
+// press passes the press of the pointer at (x, y) to the handlers of the
+// child, if it is inside the frame, and reports whether one handled it.
+// The pointer is the touch ID, or -1 for the mouse.
+func (c *child) press(frame *image.Rectangle, pointer ebiten.TouchID, x, y int) bool {
+	if !isInside(frame, x, y) {
+		return false
 	}
-	if !result && c.checkTouchHandlerStart(frame, touchID, x, y) {
-		result = true
+	mouse := pointer == -1
+	if h, ok := c.item.Handler.(MouseLeftButtonHandler); ok && mouse {
+		if h.HandleJustPressedMouseButtonLeft(x, y) {
+			c.isMouseLeftButtonHandler = true
+			return true
+		}
 	}
-	c.checkSwipeHandlerStart(frame, touchID, x, y)
-	return result
-}
-
-func (c *child) HandleJustReleasedTouchID(
-	frame *image.Rectangle, touchID ebiten.TouchID, x, y int) {
-	c.checkTouchHandlerEnd(frame, touchID, x, y)
-	c.checkButtonHandlerEnd(frame, touchID, x, y)
-	c.checkSwipeHandlerEnd(frame, touchID, x, y)
-}
-
-func (c *child) checkTouchHandlerStart(frame *image.Rectangle, touchID ebiten.TouchID, x, y int) bool {
-	touchHandler, ok := c.item.Handler.(TouchHandler)
-	if ok {
-		if isInside(frame, x, y) {
-			if touchHandler.HandleJustPressedTouchID(touchID, x, y) {
-				c.handledTouchID = touchID
-				return true
+	if button, ok := c.item.button(); ok {
+		if !c.isButtonPressed {
+			c.isButtonPressed = true
+			if mouse {
+				c.isMouseLeftButtonHandler = true
+			} else {
+				c.handledTouchID = pointer
 			}
+			button.HandlePress(x, y, pointer)
+		}
+		return true
+	}
+	if h, ok := c.item.Handler.(TouchHandler); ok && !mouse {
+		if h.HandleJustPressedTouchID(pointer, x, y) {
+			c.handledTouchID = pointer
+			return true
 		}
 	}
 	return false
 }
 
-func (c *child) checkTouchHandlerEnd(frame *image.Rectangle, touchID ebiten.TouchID, x, y int) {
-	touchHandler, ok := c.item.Handler.(TouchHandler)
-	if ok {
-		if c.handledTouchID == touchID {
-			touchHandler.HandleJustReleasedTouchID(touchID, x, y)
-			c.handledTouchID = -1
-		}
+// pressedBy reports whether the handlers of the child are pressed by the pointer.
+func (c *child) pressedBy(pointer ebiten.TouchID) bool {
+	if pointer == -1 {
+		return c.isMouseLeftButtonHandler
 	}
+	return c.handledTouchID == pointer
 }
 
-func (c *child) checkSwipeHandlerStart(frame *image.Rectangle, touchID ebiten.TouchID, x, y int) bool {
-	_, ok := c.item.Handler.(SwipeHandler)
-	if ok {
-		if isInside(frame, x, y) {
-			c.swipeTouchID = touchID
-			c.swipe.downTime = time.Now()
-			c.swipe.downX, c.swipe.downY = x, y
-			return true
+// release passes the release of the pointer at (x, y) to the handlers of
+// the child pressed by it. The press of a button is cancelled if cancel is
+// true or if (x, y) is outside of the frame.
+func (c *child) release(frame *image.Rectangle, pointer ebiten.TouchID, x, y int, cancel bool) {
+	if !c.pressedBy(pointer) {
+		return
+	}
+	mouse := pointer == -1
+	if mouse {
+		c.isMouseLeftButtonHandler = false
+		if h, ok := c.item.Handler.(MouseLeftButtonHandler); ok {
+			h.HandleJustReleasedMouseButtonLeft(x, y)
+		}
+	} else {
+		c.handledTouchID = -1
+		if h, ok := c.item.Handler.(TouchHandler); ok {
+			h.HandleJustReleasedTouchID(pointer, x, y)
 		}
 	}
-	return false
+	if button, ok := c.item.Handler.(ButtonHandler); ok && c.isButtonPressed {
+		c.isButtonPressed = false
+		if !cancel {
+			if x == 0 && y == 0 {
+				// The position of the release is unknown.
+				cancel = mouse
+			} else {
+				cancel = !isInside(frame, x, y)
+			}
+		}
+		button.HandleRelease(x, y, cancel)
+	}
 }
 
-func (c *child) checkSwipeHandlerEnd(frame *image.Rectangle, touchID ebiten.TouchID, x, y int) bool {
-	swipeHandler, ok := c.item.Handler.(SwipeHandler)
-	if ok {
-		if c.swipeTouchID != touchID {
-			return false
-		}
-		c.swipeTouchID = -1
-		c.upTime = time.Now()
-		c.upX, c.upY = x, y
-		if c.checkSwipe() {
-			swipeHandler.HandleSwipe(c.swipeDir)
-			return true
-		}
+// button returns the ButtonHandler of the view, unless its handler
+// reports that it is not a button with NotButton.
+func (v *View) button() (ButtonHandler, bool) {
+	button, ok := v.Handler.(ButtonHandler)
+	if !ok {
+		return nil, false
 	}
-	return false
+	if b, ok := v.Handler.(NotButton); ok && !b.IsButton() {
+		return nil, false
+	}
+	return button, true
 }
 
 const swipeThresholdDist = 50.
 const swipeThresholdTime = time.Millisecond * 300
 
-func (c *child) checkSwipe() bool {
-	dur := c.upTime.Sub(c.downTime)
+// swipeDirection returns the direction of a pointer moved from the point
+// down to the point up during dur, if it is a swipe.
+func swipeDirection(down, up image.Point, dur time.Duration) (SwipeDirection, bool) {
 	if dur > swipeThresholdTime {
-		return false
+		return 0, false
 	}
 
-	deltaX := float64(c.downX - c.upX)
+	deltaX := float64(down.X - up.X)
 	if math.Abs(deltaX) >= swipeThresholdDist {
 		if deltaX > 0 {
-			c.swipeDir = SwipeDirectionLeft
-		} else {
-			c.swipeDir = SwipeDirectionRight
+			return SwipeDirectionLeft, true
 		}
-		return true
+		return SwipeDirectionRight, true
 	}
 
-	deltaY := float64(c.downY - c.upY)
+	deltaY := float64(down.Y - up.Y)
 	if math.Abs(deltaY) >= swipeThresholdDist {
 		if deltaY > 0 {
-			c.swipeDir = SwipeDirectionUp
-		} else {
-			c.swipeDir = SwipeDirectionDown
+			return SwipeDirectionUp, true
 		}
-		return true
+		return SwipeDirectionDown, true
 	}
 
-	return false
-}
-
-func (c *child) checkButtonHandlerStart(frame *image.Rectangle, touchID ebiten.TouchID, x, y int) bool {
-	button, ok := c.item.Handler.(ButtonHandler)
-	if ok {
-		for {
-			if button, ok := c.item.Handler.(NotButton); ok {
-				if !button.IsButton() {
-					break
-				}
-			}
-			if isInside(frame, x, y) {
-				if !c.isButtonPressed {
-					c.isButtonPressed = true
-					c.handledTouchID = touchID
-					button.HandlePress(x, y, touchID)
-				}
-				return true
-			} else if c.handledTouchID == touchID {
-				c.handledTouchID = -1
-			}
-			break
-		}
-	}
-	return false
-}
-
-func (c *child) checkButtonHandlerEnd(frame *image.Rectangle, touchID ebiten.TouchID, x, y int) {
-	button, ok := c.item.Handler.(ButtonHandler)
-	if ok {
-		if c.handledTouchID == touchID {
-			if c.isButtonPressed {
-				c.isButtonPressed = false
-				c.handledTouchID = -1
-				if x == 0 && y == 0 {
-					button.HandleRelease(x, y, false)
-				} else {
-					button.HandleRelease(x, y, !isInside(frame, x, y))
-				}
-			}
-		}
-	}
+	return 0, false
 }
@@ -28,8 +28,8 @@ func TestHandlers(t *testing.T) {
 			flex := &View{
 				Width:      300,
 				Height:     500,
-				Left:       100,
-				Top:        50,
+				Left:       Int(100),
+				Top:        Int(50),
 				Position:   PositionAbsolute,
 				Direction:  Column,
 				Justify:    JustifyCenter,
@@ -203,8 +203,8 @@ func testMouchClick(t *testing.T, flex *View, h *mockHandler, frame image.Rectan
 		t.Run(tt.Scenario, func(t *testing.T) {
 			h.Init()
 
-			flex.handleMouseButtonLeftPressed(tt.Start.X, tt.Start.Y)
-			flex.handleMouseButtonLeftReleased(tt.End.X, tt.End.Y)
+			flex.pressPointer(-1, tt.Start.X, tt.Start.Y)
+			flex.releasePointer(-1, tt.End.X, tt.End.Y)
 
 			assert.Equal(t, tt.Want, result{h.IsPressed, h.IsReleased, h.IsCancel})
 		})
@@ -257,7 +257,7 @@ func testMouseMove(t *testing.T, flex *View, h *mockHandler, frame image.Rectang
 		t.Run(tt.Scenario, func(t *testing.T) {
 			h.Init()
 
-			flex.handleMouse(tt.Point.X, tt.Point.Y)
+			flex.moveMouse(tt.Point.X, tt.Point.Y)
 
 			assert.Equal(t, tt.Want, result{h.IsMouseMoved, h.MousePoint})
 		})
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"image"
 	"image/color"
+	"sort"
 
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
@@ -17,19 +18,46 @@ type containerEmbed struct {
 	frame    image.Rectangle
 	touchIDs []ebiten.TouchID
 
+	// scrollDrags are the pointers that may drag scroll views.
+	// They are only tracked by the root.
+	scrollDrags []*scrollDrag
+
+	// presses, hovered and move are the state of the pointer events,
+	// only tracked by the root.
+	presses map[ebiten.TouchID]pointerPress
+	hovered []*View // the views under the mouse cursor, innermost first
+	move    *Event  // the last EventMove dispatched
+
+	// layers caches the stacking order of the descendants, or is nil if
+	// it must be computed again.
+	layers []layer
+
 	calculatedWidth  int
 	calculatedHeight int
 }
 
-func (ct *containerEmbed) processEvent() {
-	ct.handleTouchEvents()
-	ct.handleMouseEvents()
+func (v *View) processEvent() {
+	v.handleTouchEvents()
+	v.handleMouseEvents()
 }
 
-// Draw draws it's children
+// Draw draws it's descendants in the stacking order.
 func (ct *containerEmbed) Draw(screen *ebiten.Image) {
-	for _, c := range ct.children {
-		ct.drawChild(screen, c)
+	for _, l := range ct.stackingOrder() {
+		if l.hidden {
+			continue
+		}
+		dst := screen
+		if l.clip != nil {
+			if !l.parent.computeBounds(l.child).Overlaps(*l.clip) {
+				// Entirely clipped out.
+				continue
+			}
+			if screen != nil {
+				dst = screen.SubImage(*l.clip).(*ebiten.Image)
+			}
+		}
+		l.parent.drawChild(dst, l.child)
 	}
 }
 
@@ -38,7 +66,6 @@ func (ct *containerEmbed) drawChild(screen *ebiten.Image, child *child) {
 	if ct.shouldDrawChild(child) {
 		ct.handleDraw(screen, b, child)
 	}
-	child.item.Draw(screen)
 	ct.debugDraw(screen, b, child)
 }
 
@@ -74,163 +101,22 @@ func (ct *containerEmbed) debugDraw(screen *ebiten.Image, b image.Rectangle, chi
 	}
 }
 
-func (ct *containerEmbed) HandleJustPressedTouchID(touchID ebiten.TouchID, x, y int) bool {
-	for c := len(ct.children) - 1; c >= 0; c-- {
-		child := ct.children[c]
-		childFrame := ct.childFrame(child)
-		if child.item.Display == DisplayNone {
-			continue
-		}
-		if child.HandleJustPressedTouchID(childFrame, touchID, x, y) {
-			return true
-		}
-		if child.item.HandleJustPressedTouchID(touchID, x, y) {
-			return true
-		}
-	}
-	return false
-}
-
-func (ct *containerEmbed) HandleJustReleasedTouchID(touchID ebiten.TouchID, x, y int) {
-	for c := len(ct.children) - 1; c >= 0; c-- {
-		child := ct.children[c]
-		childFrame := ct.childFrame(child)
-		child.HandleJustReleasedTouchID(childFrame, touchID, x, y)
-		child.item.HandleJustReleasedTouchID(touchID, x, y)
-	}
+// HandleJustPressedTouchID dispatches the press of the touch at (x, y),
+// and reports whether a handler of the views under it handled it.
+func (v *View) HandleJustPressedTouchID(touchID ebiten.TouchID, x, y int) bool {
+	return v.pressPointer(touchID, x, y)
 }
 
-func (ct *containerEmbed) handleMouse(x, y int) bool {
-	for c := len(ct.children) - 1; c >= 0; c-- {
-		child := ct.children[c]
-		childFrame := ct.childFrame(child)
-		if child.item.Display == DisplayNone {
-			continue
-		}
-		mouseHandler, ok := child.item.Handler.(MouseHandler)
-		if ok && mouseHandler != nil {
-			if isInside(childFrame, x, y) {
-				if mouseHandler.HandleMouse(x, y) {
-					return true
-				}
-			}
-		}
-		if child.item.handleMouse(x, y) {
-			return true
-		}
-	}
-	return false
-}
-
-func (ct *containerEmbed) handleMouseEnterLeave(x, y int) bool {
-	result := false
-	for c := len(ct.children) - 1; c >= 0; c-- {
-		child := ct.children[c]
-		childFrame := ct.childFrame(child)
-		if child.item.Display == DisplayNone {
-			continue
-		}
-		mouseHandler, ok := child.item.Handler.(MouseEnterLeaveHandler)
-		if ok {
-			if !result && !child.isMouseEntered && isInside(childFrame, x, y) {
-				if mouseHandler.HandleMouseEnter(x, y) {
-					result = true
-					child.isMouseEntered = true
-				}
-			}
-
-			if child.isMouseEntered && !isInside(childFrame, x, y) {
-				child.isMouseEntered = false
-				mouseHandler.HandleMouseLeave()
-			}
-		}
-
-		if child.item.handleMouseEnterLeave(x, y) {
-			result = true
-		}
-	}
-	return result
-}
-
-func (ct *containerEmbed) handleMouseButtonLeftPressed(x, y int) bool {
-	result := false
-
-	for c := len(ct.children) - 1; c >= 0; c-- {
-		child := ct.children[c]
-		childFrame := ct.childFrame(child)
-		if child.item.Display == DisplayNone {
-			continue
-		}
-		mouseLeftClickHandler, ok := child.item.Handler.(MouseLeftButtonHandler)
-		if ok {
-			if !result && isInside(childFrame, x, y) {
-				if mouseLeftClickHandler.HandleJustPressedMouseButtonLeft(x, y) {
-					result = true
-					child.isMouseLeftButtonHandler = true
-				}
-			}
-		}
-
-		button, ok := child.item.Handler.(ButtonHandler)
-		if ok {
-			for {
-				if button, ok := child.item.Handler.(NotButton); ok {
-					if !button.IsButton() {
-						break
-					}
-				}
-				if !result && isInside(childFrame, x, y) {
-					if !child.isButtonPressed {
-						child.isButtonPressed = true
-						child.isMouseLeftButtonHandler = true
-						result = true
-						button.HandlePress(x, y, -1)
-					}
-				}
-				break
-			}
-		}
-
-		if !result && child.item.handleMouseButtonLeftPressed(x, y) {
-			result = true
-		}
-	}
-	return result
-}
-
-func (ct *containerEmbed) handleMouseButtonLeftReleased(x, y int) {
-	for c := len(ct.children) - 1; c >= 0; c-- {
-		child := ct.children[c]
-		mouseLeftClickHandler, ok := child.item.Handler.(MouseLeftButtonHandler)
-		if ok {
-			if child.isMouseLeftButtonHandler {
-				child.isMouseLeftButtonHandler = false
-				mouseLeftClickHandler.HandleJustReleasedMouseButtonLeft(x, y)
-			}
-		}
-
-		button, ok := child.item.Handler.(ButtonHandler)
-		if ok {
-			if child.isButtonPressed && child.isMouseLeftButtonHandler {
-				child.isButtonPressed = false
-				child.isMouseLeftButtonHandler = false
-				if x == 0 && y == 0 {
-					button.HandleRelease(x, y, true)
-				} else {
-					button.HandleRelease(x, y, !isInside(ct.childFrame(child), x, y))
-				}
-			}
-		}
-
-		child.item.handleMouseButtonLeftReleased(x, y)
-	}
+// HandleJustReleasedTouchID dispatches the release of the touch at (x, y).
+func (v *View) HandleJustReleasedTouchID(touchID ebiten.TouchID, x, y int) {
+	v.releasePointer(touchID, x, y)
 }
 
 func isInside(r *image.Rectangle, x, y int) bool {
 	return r.Min.X <= x && x <= r.Max.X && r.Min.Y <= y && y <= r.Max.Y
 }
 
-func (ct *containerEmbed) handleTouchEvents() {
+func (v *View) handleTouchEvents() {
 	justPressedTouchIds := inpututil.AppendJustPressedTouchIDs(nil)
 
 	if justPressedTouchIds != nil {
@@ -239,38 +125,179 @@ func (ct *containerEmbed) handleTouchEvents() {
 			x, y := ebiten.TouchPosition(touchID)
 			recordTouchPosition(touchID, x, y)
 
-			ct.HandleJustPressedTouchID(touchID, x, y)
-			ct.touchIDs = append(ct.touchIDs, touchID)
+			v.pressPointer(touchID, x, y)
+			v.touchIDs = append(v.touchIDs, touchID)
 		}
 	}
 
-	touchIDs := ct.touchIDs
+	touchIDs := v.touchIDs
 	for t := range touchIDs {
 		if inpututil.IsTouchJustReleased(touchIDs[t]) {
 			pos := lastTouchPosition(touchIDs[t])
-			ct.HandleJustReleasedTouchID(touchIDs[t], pos.X, pos.Y)
+			v.releasePointer(touchIDs[t], pos.X, pos.Y)
 		} else {
 			x, y := ebiten.TouchPosition(touchIDs[t])
 			recordTouchPosition(touchIDs[t], x, y)
+			v.moveScrollDrag(touchIDs[t], x, y)
 		}
 	}
 }
 
-func (ct *containerEmbed) handleMouseEvents() {
+func (v *View) handleMouseEvents() {
 	x, y := ebiten.CursorPosition()
-	ct.handleMouse(x, y)
-	ct.handleMouseEnterLeave(x, y)
+	v.moveMouse(x, y)
+	if dx, dy := ebiten.Wheel(); dx != 0 || dy != 0 {
+		v.handleWheel(x, y, dx, dy)
+	}
 	if inpututil.IsMouseButtonJustPressed((ebiten.MouseButtonLeft)) {
-		ct.handleMouseButtonLeftPressed(x, y)
+		v.pressPointer(-1, x, y)
+	} else if ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft) {
+		v.moveScrollDrag(-1, x, y)
 	}
 	if inpututil.IsMouseButtonJustReleased((ebiten.MouseButtonLeft)) {
-		ct.handleMouseButtonLeftReleased(x, y)
+		v.releasePointer(-1, x, y)
+	}
+}
+
+// orderedChildren returns the children sorted by their order property.
+// Children with the same order keep the order in which they were added.
+func (ct *containerEmbed) orderedChildren() []*child {
+	sorted := true
+	for i := 1; i < len(ct.children); i++ {
+		if ct.children[i-1].item.Order > ct.children[i].item.Order {
+			sorted = false
+			break
+		}
+	}
+	if sorted {
+		return ct.children
+	}
+	children := make([]*child, len(ct.children))
+	copy(children, ct.children)
+	sort.SliceStable(children, func(i, j int) bool {
+		return children[i].item.Order < children[j].item.Order
+	})
+	return children
+}
+
+// layer is a descendant in the stacking order, with the container it
+// belongs to.
+type layer struct {
+	parent *containerEmbed
+	child  *child
+	hidden bool             // whether an ancestor of the child is hidden
+	clip   *image.Rectangle // the clip of the ancestors with overflow, if any
+}
+
+// noHit is a frame that no point is inside of.
+var noHit = image.Rectangle{Min: image.Pt(0, 0), Max: image.Pt(-1, -1)}
+
+// frame returns the frame of the child for hit testing,
+// which excludes the regions that are clipped out.
+func (l *layer) frame() *image.Rectangle {
+	r := l.parent.childFrame(l.child)
+	if l.clip == nil {
+		return r
+	}
+	if !r.Overlaps(*l.clip) {
+		return &noHit
+	}
+	clipped := r.Intersect(*l.clip)
+	return &clipped
+}
+
+// stackingOrder returns the descendants that are displayed, in the order
+// they are drawn. Hit testing uses the reverse order, so that the topmost
+// view receives the events first. As children are drawn after their parent,
+// a child is hit before its parent even without z-indexes: of nested
+// buttons, the innermost one under the pointer is pressed, not the outermost.
+//
+// Descendants are drawn in tree order, parents before their children, and
+// stably sorted by their z-index. A view with a non-zero z-index establishes
+// a stacking context: its descendants are sorted among themselves and drawn
+// right after it, so they are never interleaved with the views outside of it.
+// Other descendants, including absolutely positioned ones nested in other
+// views, are sorted together with the children of the nearest such view or
+// the root.
+//
+// Descendants of views whose overflow is not visible are clipped to the
+// frames of those views.
+//
+// The order is cached until the descendants or their layout change.
+func (ct *containerEmbed) stackingOrder() []layer {
+	if ct.layers == nil {
+		ct.layers = ct.stack(false, nil)
+	}
+	return ct.layers
+}
+
+func (ct *containerEmbed) stack(hidden bool, clip *image.Rectangle) []layer {
+	type entry struct {
+		layer
+		context []layer
+	}
+	var entries []entry
+	var walk func(parent *containerEmbed, hidden bool, clip *image.Rectangle)
+	walk = func(parent *containerEmbed, hidden bool, clip *image.Rectangle) {
+		for _, c := range parent.orderedChildren() {
+			if c.item.Display == DisplayNone {
+				continue
+			}
+			e := entry{layer: layer{parent: parent, child: c, hidden: hidden, clip: clip}}
+			childClip := clip
+			if c.item.Overflow != OverflowVisible {
+				r := c.item.frame
+				if clip != nil {
+					r = r.Intersect(*clip)
+				}
+				childClip = &r
+			}
+			if c.item.ZIndex != 0 {
+				e.context = c.item.stack(hidden || c.item.Hidden, childClip)
+				entries = append(entries, e)
+				continue
+			}
+			entries = append(entries, e)
+			walk(&c.item.containerEmbed, hidden || c.item.Hidden, childClip)
+		}
+	}
+	walk(ct, hidden, clip)
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].child.item.ZIndex < entries[j].child.item.ZIndex
+	})
+	layers := make([]layer, 0, len(entries))
+	for _, e := range entries {
+		layers = append(layers, e.layer)
+		layers = append(layers, e.context...)
 	}
+	return layers
 }
 
+// setFrame sets the frame of the container. The children are laid out
+// again only if the size changed, otherwise they are moved along.
 func (ct *containerEmbed) setFrame(frame image.Rectangle) {
-	ct.frame = frame
-	ct.isDirty = true
+	if frame.Size() != ct.frame.Size() {
+		ct.frame = frame
+		ct.isDirty = true
+		return
+	}
+	ct.translate(frame.Min.Sub(ct.frame.Min))
+}
+
+// translate moves the frames of the container and its descendants by d.
+func (ct *containerEmbed) translate(d image.Point) {
+	if d == (image.Point{}) {
+		return
+	}
+	ct.frame = ct.frame.Add(d)
+	ct.layers = nil
+	for _, c := range ct.children {
+		if c.absolute {
+			c.bounds = c.bounds.Add(d)
+		}
+		c.item.translate(d)
+	}
 }
 
 func (ct *containerEmbed) childFrame(c *child) *image.Rectangle {
@@ -4,6 +4,7 @@ import (
 	"image"
 	"testing"
 
+	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/stretchr/testify/require"
 )
 
@@ -32,3 +33,241 @@ func TestIsInside(t *testing.T) {
 		require.Equal(t, tt.want, isInside(&tt.r, tt.x, tt.y))
 	}
 }
+
+func TestOrderHitTest(t *testing.T) {
+	root := &View{Width: 100, Height: 100}
+
+	mocks := [2]mockHandler{}
+	views := [2]*View{
+		{Position: PositionAbsolute, Width: 50, Height: 50, Order: 1, Handler: &mocks[0]},
+		{Position: PositionAbsolute, Width: 50, Height: 50, Handler: &mocks[1]},
+	}
+	root.AddChild(views[0], views[1])
+	root.Update()
+
+	// The child with the greater order is drawn last, so it receives the press.
+	root.pressPointer(-1, 10, 10)
+	require.True(t, mocks[0].IsPressed)
+	require.False(t, mocks[1].IsPressed)
+
+	// Reordering keeps the press state of the child.
+	views[0].SetOrder(-1)
+	root.Update()
+	root.releasePointer(-1, 10, 10)
+	require.True(t, mocks[0].IsReleased)
+	require.False(t, mocks[0].IsCancel)
+
+	root.pressPointer(-1, 10, 10)
+	require.True(t, mocks[1].IsPressed)
+}
+
+func TestZIndex(t *testing.T) {
+	var drawn []string
+	newMock := func(name string) *drawLogger {
+		return &drawLogger{name: name, log: &drawn}
+	}
+
+	t.Run("siblings", func(t *testing.T) {
+		drawn = nil
+		a, b := newMock("a"), newMock("b")
+		root := (&View{Width: 100, Height: 100}).AddChild(
+			&View{Position: PositionAbsolute, Width: 50, Height: 50, ZIndex: 1, Handler: a},
+			&View{Position: PositionAbsolute, Width: 50, Height: 50, Handler: b},
+			&View{Position: PositionAbsolute, Width: 50, Height: 50, ZIndex: -1, Handler: newMock("c")},
+		)
+		root.Update()
+		root.Draw(nil)
+		require.Equal(t, []string{"c", "b", "a"}, drawn)
+
+		root.pressPointer(-1, 10, 10)
+		require.True(t, a.IsPressed)
+		require.False(t, b.IsPressed)
+	})
+
+	t.Run("nested absolute popup", func(t *testing.T) {
+		drawn = nil
+		popup, below := newMock("popup"), newMock("below")
+		root := (&View{Width: 100, Height: 100, Direction: Column}).AddChild(
+			(&View{Width: 100, Height: 20, Handler: newMock("menu")}).AddChild(
+				&View{Position: PositionAbsolute, Width: 50, Height: 80, ZIndex: 1, Handler: popup},
+			),
+			&View{Width: 100, Height: 80, Handler: below},
+		)
+		root.Update()
+		root.Draw(nil)
+		require.Equal(t, []string{"menu", "below", "popup"}, drawn)
+
+		root.HandleJustPressedTouchID(0, 10, 50)
+		require.True(t, popup.IsPressed)
+		require.False(t, below.IsPressed)
+	})
+
+	t.Run("stacking context", func(t *testing.T) {
+		drawn = nil
+		root := (&View{Width: 100, Height: 100}).AddChild(
+			(&View{Width: 50, Height: 50, ZIndex: 1, Handler: newMock("a")}).AddChild(
+				&View{Width: 10, Height: 10, ZIndex: 100, Handler: newMock("a1")},
+				&View{Width: 10, Height: 10, ZIndex: -1, Handler: newMock("a2")},
+			),
+			&View{Width: 50, Height: 50, ZIndex: 2, Handler: newMock("b")},
+		)
+		root.Update()
+		root.Draw(nil)
+		require.Equal(t, []string{"a", "a2", "a1", "b"}, drawn)
+	})
+
+	t.Run("children on top of parent", func(t *testing.T) {
+		parent, child := newMock("parent"), newMock("child")
+		root := (&View{Width: 100, Height: 100}).AddChild(
+			(&View{Width: 50, Height: 50, Handler: parent}).AddChild(
+				&View{Width: 10, Height: 10, Handler: child},
+			),
+		)
+		root.Update()
+		root.Draw(nil)
+
+		root.pressPointer(-1, 5, 5)
+		require.True(t, child.IsPressed)
+		require.False(t, parent.IsPressed)
+		root.releasePointer(-1, 5, 5)
+		require.True(t, child.IsReleased)
+		require.False(t, parent.IsReleased)
+
+		// The children are hit first with touches too, and the parent
+		// is pressed outside of them.
+		child.Init()
+		root.HandleJustPressedTouchID(0, 5, 5)
+		require.True(t, child.IsPressed)
+		require.False(t, parent.IsPressed)
+		root.HandleJustPressedTouchID(1, 30, 30)
+		require.True(t, parent.IsPressed)
+	})
+}
+
+func TestOverflow(t *testing.T) {
+	var tests = []struct {
+		name     string
+		overflow Overflow
+		press    image.Point
+		pressed  [3]bool
+		drawn    [3]bool
+	}{
+		{
+			name:     "visible",
+			overflow: OverflowVisible,
+			press:    image.Pt(10, 100),
+			pressed:  [3]bool{false, false, true},
+			drawn:    [3]bool{true, true, true},
+		},
+		{
+			name:     "hidden, inside the clip",
+			overflow: OverflowHidden,
+			press:    image.Pt(10, 45),
+			pressed:  [3]bool{false, true, false},
+			drawn:    [3]bool{true, true, false},
+		},
+		{
+			name:     "hidden, clipped out part of an item",
+			overflow: OverflowHidden,
+			press:    image.Pt(10, 60),
+			pressed:  [3]bool{false, false, false},
+			drawn:    [3]bool{true, true, false},
+		},
+		{
+			name:     "scroll clips as well",
+			overflow: OverflowScroll,
+			press:    image.Pt(10, 100),
+			pressed:  [3]bool{false, false, false},
+			drawn:    [3]bool{true, true, false},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mocks := [3]mockHandler{}
+			panel := &View{
+				Width:      100,
+				Height:     50,
+				Direction:  Column,
+				AlignItems: AlignItemStart,
+				Overflow:   tt.overflow,
+			}
+			for i := range mocks {
+				panel.AddChild(&View{Width: 100, Height: 40, Handler: &mocks[i]})
+			}
+			root := (&View{Width: 200, Height: 200, AlignItems: AlignItemStart}).AddChild(panel)
+
+			root.Update()
+			root.Draw(nil)
+			root.pressPointer(-1, tt.press.X, tt.press.Y)
+
+			for i := range mocks {
+				require.Equal(t, tt.pressed[i], mocks[i].IsPressed, "pressed %d", i)
+				require.Equal(t, tt.drawn[i], mocks[i].IsDrawn, "drawn %d", i)
+			}
+		})
+	}
+}
+
+func TestOverflowNested(t *testing.T) {
+	inner := &View{Width: 100, Height: 100, Overflow: OverflowHidden}
+	inner.AddChild(&View{Width: 100, Height: 100})
+	outer := (&View{Width: 60, Height: 60, Overflow: OverflowHidden}).AddChild(inner)
+	root := (&View{Width: 200, Height: 200, AlignItems: AlignItemStart}).AddChild(outer)
+	root.Update()
+
+	layers := root.stackingOrder()
+	require.Len(t, layers, 3)
+	require.Nil(t, layers[0].clip)
+	require.Equal(t, image.Rect(0, 0, 60, 60), *layers[1].clip)
+	require.Equal(t, image.Rect(0, 0, 60, 60), *layers[2].clip)
+	require.Equal(t, image.Rect(0, 0, 60, 60), *layers[2].frame())
+}
+
+func TestStackingOrderCache(t *testing.T) {
+	a, b := &View{Width: 10, Height: 10}, &View{Width: 10, Height: 10}
+	panel := (&View{Width: 50, Height: 50}).AddChild(a)
+	root := (&View{Width: 100, Height: 100}).AddChild(panel, b)
+	root.Update()
+
+	items := func() []*View {
+		var items []*View
+		for _, l := range root.stackingOrder() {
+			items = append(items, l.child.item)
+		}
+		return items
+	}
+	require.Equal(t, []*View{panel, a, b}, items())
+	layers := root.stackingOrder()
+	require.Same(t, &layers[0], &root.stackingOrder()[0], "the order is cached")
+
+	a.SetZIndex(1)
+	require.Equal(t, []*View{panel, b, a}, items(), "changing a descendant invalidates the cache")
+
+	c := &View{Width: 10, Height: 10}
+	panel.AddChild(c)
+	require.Equal(t, []*View{panel, c, b, a}, items())
+
+	panel.RemoveChild(a)
+	require.Equal(t, []*View{panel, c, b}, items())
+
+	panel.SetOverflow(OverflowHidden)
+	root.Update()
+	require.Equal(t, image.Rect(0, 0, 50, 50), *root.stackingOrder()[1].clip)
+	root.SetWidth(200)
+	panel.SetPosition(PositionAbsolute)
+	panel.SetLeft(20)
+	root.stackingOrder() // cached before the layout
+	root.Update()
+	require.Equal(t, image.Rect(20, 0, 70, 50), *root.stackingOrder()[1].clip, "laying out invalidates the cache")
+}
+
+type drawLogger struct {
+	mockHandler
+	name string
+	log  *[]string
+}
+
+func (h *drawLogger) HandleDraw(screen *ebiten.Image, frame image.Rectangle) {
+	*h.log = append(*h.log, h.name)
+	h.mockHandler.HandleDraw(screen, frame)
+}
@@ -0,0 +1,404 @@
+package furex
+
+import (
+	"image"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// EventType is the type of a pointer event.
+type EventType int
+
+const (
+	// EventPress is dispatched when a touch or the left mouse button is pressed.
+	EventPress EventType = iota
+	// EventRelease is dispatched when a touch or the left mouse button is released.
+	EventRelease
+	// EventMove is dispatched when the mouse cursor moves, or when the
+	// view under it changes.
+	EventMove
+	// EventEnter is dispatched to each view that the mouse cursor enters.
+	// It doesn't bubble.
+	EventEnter
+	// EventLeave is dispatched to each view that the mouse cursor leaves.
+	// It doesn't bubble.
+	EventLeave
+	// EventSwipe is dispatched when a touch is swiped, to the view
+	// that was under the touch when it was pressed.
+	EventSwipe
+)
+
+func (t EventType) String() string {
+	switch t {
+	case EventPress:
+		return "press"
+	case EventRelease:
+		return "release"
+	case EventMove:
+		return "move"
+	case EventEnter:
+		return "enter"
+	case EventLeave:
+		return "leave"
+	case EventSwipe:
+		return "swipe"
+	}
+	return "unknown"
+}
+
+// EventPhase is the phase of the propagation of an event.
+type EventPhase int
+
+const (
+	// EventPhaseNone means the event is not being dispatched.
+	EventPhaseNone EventPhase = iota
+	// EventPhaseCapture is the phase in which the event goes down from
+	// the root to the parent of the target.
+	EventPhaseCapture
+	// EventPhaseTarget is the phase in which the event is at the target.
+	EventPhaseTarget
+	// EventPhaseBubble is the phase in which the event goes up from
+	// the parent of the target to the root.
+	EventPhaseBubble
+)
+
+func (p EventPhase) String() string {
+	switch p {
+	case EventPhaseNone:
+		return "none"
+	case EventPhaseCapture:
+		return "capture"
+	case EventPhaseTarget:
+		return "target"
+	case EventPhaseBubble:
+		return "bubble"
+	}
+	return "unknown"
+}
+
+// Event is a pointer event dispatched through the tree of views.
+//
+// The event goes down from the root to its target in the capture phase,
+// calling the EventCapturer of each view, and then goes back up from the
+// target to the root, calling the EventHandler of each view.
+//
+// The handler interfaces such as ButtonHandler, TouchHandler,
+// MouseHandler and SwipeHandler are the default action of the events:
+// after the event has propagated, unless PreventDefault was called, they
+// are called for the target and then for the ancestors the event reached.
+// A press or a move is taken by the first of them that handles it.
+// A button pressed by a pointer is released even if the release of the
+// pointer is over another view, but the press is cancelled if the release
+// was stopped before reaching the button or its default was prevented.
+//
+// MouseEnterLeaveHandler is called with EventEnter and EventLeave
+// of its view, which have no default action.
+type Event struct {
+	Type  EventType
+	Phase EventPhase
+	// Target is the topmost view under the pointer that handles pointer
+	// events, or the root if there is none.
+	Target *View
+	// CurrentTarget is the view whose handler is being called.
+	CurrentTarget *View
+	// X and Y are the location of the pointer relative to the window (0,0).
+	X, Y int
+	// TouchID is the touch of the event, or -1 for the mouse.
+	TouchID ebiten.TouchID
+	// SwipeDirection is the direction of an EventSwipe.
+	SwipeDirection SwipeDirection
+
+	stopped   bool
+	prevented bool
+	handler   *View   // the view whose handler took the press or the move
+	reached   []*View // the target and the ancestors, in the bubble phase
+}
+
+// StopPropagation stops the event from reaching the views after the
+// current one, which also skips their default actions. The default
+// action of the views already reached still happens.
+func (e *Event) StopPropagation() {
+	e.stopped = true
+}
+
+// PreventDefault prevents the default action of the event.
+// EventEnter and EventLeave have no default action.
+func (e *Event) PreventDefault() {
+	e.prevented = true
+}
+
+// DefaultPrevented reports whether PreventDefault was called.
+func (e *Event) DefaultPrevented() bool {
+	return e.prevented
+}
+
+// Bubbles reports whether the event goes up from its target to the root.
+func (e *Event) Bubbles() bool {
+	return e.Type != EventEnter && e.Type != EventLeave
+}
+
+// dispatchEvent dispatches the event to the ancestors of its target in
+// the capture phase, to the target, and to the ancestors again in the
+// bubble phase, and then performs the default action of the views it
+// reached. It reports whether the default action was not prevented.
+func dispatchEvent(e *Event) bool {
+	var ancestors []*View // the ancestors of the target, parent first
+	for v := e.Target; v.hasParent; v = v.parent {
+		ancestors = append(ancestors, v.parent)
+	}
+
+	e.Phase = EventPhaseCapture
+	for i := len(ancestors) - 1; i >= 0 && !e.stopped; i-- {
+		e.CurrentTarget = ancestors[i]
+		if h, ok := ancestors[i].Handler.(EventCapturer); ok {
+			h.CaptureEvent(e)
+		}
+	}
+	e.reached = nil
+	if !e.stopped {
+		e.Phase = EventPhaseTarget
+		e.CurrentTarget = e.Target
+		if h, ok := e.Target.Handler.(EventHandler); ok {
+			h.HandleEvent(e)
+		}
+		e.reached = append(e.reached, e.Target)
+	}
+	if e.Bubbles() {
+		e.Phase = EventPhaseBubble
+		for _, v := range ancestors {
+			if e.stopped {
+				break
+			}
+			e.CurrentTarget = v
+			if h, ok := v.Handler.(EventHandler); ok {
+				h.HandleEvent(e)
+			}
+			e.reached = append(e.reached, v)
+		}
+	}
+	e.Phase, e.CurrentTarget = EventPhaseNone, nil
+	if e.prevented {
+		return false
+	}
+	for _, v := range e.reached {
+		v.defaultAction(e)
+	}
+	return true
+}
+
+// defaultAction calls the handlers of the view for the event.
+// The root has no default action.
+func (v *View) defaultAction(e *Event) {
+	c := v.asChild()
+	if c == nil {
+		return
+	}
+	switch e.Type {
+	case EventPress:
+		if e.handler == nil && c.press(v.hitFrame(c), e.TouchID, e.X, e.Y) {
+			e.handler = v
+		}
+	case EventRelease:
+		c.release(v.hitFrame(c), e.TouchID, e.X, e.Y, false)
+	case EventMove:
+		h, ok := v.Handler.(MouseHandler)
+		if ok && e.handler == nil && isInside(v.hitFrame(c), e.X, e.Y) && h.HandleMouse(e.X, e.Y) {
+			e.handler = v
+		}
+	case EventSwipe:
+		if h, ok := v.Handler.(SwipeHandler); ok {
+			h.HandleSwipe(e.SwipeDirection)
+		}
+	}
+}
+
+// asChild returns the child of the parent of the view that holds it,
+// or nil if the view is the root.
+func (v *View) asChild() *child {
+	if !v.hasParent {
+		return nil
+	}
+	for _, c := range v.parent.children {
+		if c.item == v {
+			return c
+		}
+	}
+	return nil
+}
+
+// hitFrame returns the frame of the view for hit testing, clipped by its
+// ancestors whose overflow is not visible like the frame of its layer.
+func (v *View) hitFrame(c *child) *image.Rectangle {
+	var clip *image.Rectangle
+	for p := v.parent; p.hasParent; p = p.parent {
+		if p.Overflow != OverflowVisible {
+			r := p.frame
+			if clip != nil {
+				r = r.Intersect(*clip)
+			}
+			clip = &r
+		}
+	}
+	l := layer{parent: &v.parent.containerEmbed, child: c, clip: clip}
+	return l.frame()
+}
+
+// hitTest returns the topmost view under (x, y) that is shown and handles
+// pointer events, or the view itself if there is none.
+func (v *View) hitTest(x, y int) *View {
+	layers := v.stackingOrder()
+	for i := len(layers) - 1; i >= 0; i-- {
+		l := layers[i]
+		item := l.child.item
+		if !l.hidden && !item.Hidden && item.handlesPointer() && isInside(l.frame(), x, y) {
+			return item
+		}
+	}
+	return v
+}
+
+// handlesPointer reports whether the handler of the view handles pointer
+// events. The other views, such as labels or tints drawn over a button,
+// let the events through to the views below them.
+func (v *View) handlesPointer() bool {
+	if _, ok := v.button(); ok {
+		return true
+	}
+	switch v.Handler.(type) {
+	case EventHandler, TouchHandler, MouseHandler, MouseLeftButtonHandler,
+		MouseEnterLeaveHandler, SwipeHandler:
+		return true
+	}
+	return false
+}
+
+// pointerPress is a press of a pointer, tracked to release the view
+// pressed and to detect the swipe of a touch.
+type pointerPress struct {
+	pos     image.Point
+	time    time.Time
+	target  *View
+	pressed *View // the view whose handler took the press, if any
+}
+
+// pressPointer dispatches the press of the pointer at (x, y), and starts
+// dragging the scroll views under it unless its default was prevented.
+// The pointer is the touch ID, or -1 for the mouse. It reports whether
+// a handler took the press.
+func (v *View) pressPointer(pointer ebiten.TouchID, x, y int) bool {
+	e := &Event{Type: EventPress, Target: v.hitTest(x, y), X: x, Y: y, TouchID: pointer}
+	prevented := !dispatchEvent(e)
+	if v.presses == nil {
+		v.presses = map[ebiten.TouchID]pointerPress{}
+	}
+	v.presses[pointer] = pointerPress{pos: image.Pt(x, y), time: time.Now(), target: e.Target, pressed: e.handler}
+	if !prevented {
+		v.startScrollDrag(pointer, x, y)
+	}
+	return e.handler != nil
+}
+
+// releasePointer dispatches the release of the pointer at (x, y), and
+// releases the view pressed by it if the release didn't reach it. Then it
+// dispatches the swipe of a touch to the view under it when it was pressed.
+func (v *View) releasePointer(pointer ebiten.TouchID, x, y int) {
+	p, pressed := v.presses[pointer]
+	delete(v.presses, pointer)
+	e := &Event{Type: EventRelease, Target: v.hitTest(x, y), X: x, Y: y, TouchID: pointer}
+	dispatchEvent(e)
+	if p.pressed != nil {
+		if c := p.pressed.asChild(); c != nil {
+			c.release(p.pressed.hitFrame(c), pointer, x, y, e.stopped || e.prevented)
+		}
+	}
+	v.endScrollDrag(pointer, x, y)
+
+	if !pressed || pointer == -1 {
+		return
+	}
+	if dir, ok := swipeDirection(p.pos, image.Pt(x, y), time.Since(p.time)); ok {
+		dispatchEvent(&Event{
+			Type: EventSwipe, Target: p.target, X: x, Y: y, TouchID: pointer, SwipeDirection: dir,
+		})
+	}
+}
+
+// moveMouse dispatches the leave and enter events of the views under the
+// mouse cursor at (x, y), and its move if it moved. Otherwise the default
+// action of the last move is performed again, so that the mouse handlers
+// are called every tick as long as the move wasn't prevented.
+func (v *View) moveMouse(x, y int) {
+	target := v.hitTest(x, y)
+	v.updateHover(target, x, y)
+
+	if m := v.move; m != nil && m.X == x && m.Y == y && m.Target == target {
+		if !m.prevented {
+			m.handler = nil
+			for _, r := range m.reached {
+				r.defaultAction(m)
+			}
+		}
+		return
+	}
+	v.move = &Event{Type: EventMove, Target: target, X: x, Y: y, TouchID: -1}
+	dispatchEvent(v.move)
+}
+
+// updateHover dispatches EventLeave to the views that are no longer under
+// the mouse cursor, innermost first, and EventEnter to the views that came
+// under it, outermost first, calling their MouseEnterLeaveHandler. The
+// views under the cursor are the target and its ancestors.
+func (v *View) updateHover(target *View, x, y int) {
+	hovered := []*View{target}
+	for h := target; h.hasParent; h = h.parent {
+		hovered = append(hovered, h.parent)
+	}
+	for _, h := range v.hovered {
+		if !containsView(hovered, h) {
+			dispatchEvent(&Event{Type: EventLeave, Target: h, X: x, Y: y, TouchID: -1})
+			h.mouseLeave()
+		}
+	}
+	for i := len(hovered) - 1; i >= 0; i-- {
+		if !containsView(v.hovered, hovered[i]) {
+			dispatchEvent(&Event{Type: EventEnter, Target: hovered[i], X: x, Y: y, TouchID: -1})
+			hovered[i].mouseEnter(x, y)
+		}
+	}
+	v.hovered = hovered
+}
+
+// mouseEnter calls the MouseEnterLeaveHandler of the view entered by
+// the mouse cursor at (x, y).
+func (v *View) mouseEnter(x, y int) {
+	c := v.asChild()
+	if c == nil {
+		return
+	}
+	if h, ok := v.Handler.(MouseEnterLeaveHandler); ok && !c.isMouseEntered {
+		c.isMouseEntered = h.HandleMouseEnter(x, y)
+	}
+}
+
+// mouseLeave calls the MouseEnterLeaveHandler of the view left by the
+// mouse cursor, if it handled the enter.
+func (v *View) mouseLeave() {
+	c := v.asChild()
+	if c == nil {
+		return
+	}
+	if h, ok := v.Handler.(MouseEnterLeaveHandler); ok && c.isMouseEntered {
+		c.isMouseEntered = false
+		h.HandleMouseLeave()
+	}
+}
+
+func containsView(views []*View, v *View) bool {
+	for _, view := range views {
+		if view == v {
+			return true
+		}
+	}
+	return false
+}
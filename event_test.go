@@ -0,0 +1,272 @@
+package furex
+
+import (
+	"fmt"
+	"image"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+
+	"github.com/stretchr/testify/require"
+)
+
+type eventRecorder struct {
+	name    string
+	log     *[]string
+	capture func(e *Event)
+	handle  func(e *Event)
+}
+
+func (r *eventRecorder) record(e *Event) {
+	*r.log = append(*r.log, fmt.Sprintf("%s %s %s", r.name, e.Type, e.Phase))
+}
+
+func (r *eventRecorder) CaptureEvent(e *Event) {
+	r.record(e)
+	if r.capture != nil {
+		r.capture(e)
+	}
+}
+
+func (r *eventRecorder) HandleEvent(e *Event) {
+	r.record(e)
+	if r.handle != nil {
+		r.handle(e)
+	}
+}
+
+type eventButton struct {
+	eventRecorder
+	mockHandler
+}
+
+// hoverButton is an eventButton that records the enter and leave of the mouse.
+type hoverButton struct {
+	eventButton
+	entered, left bool
+}
+
+func (h *hoverButton) HandleMouseEnter(x, y int) bool {
+	h.entered = true
+	return true
+}
+
+func (h *hoverButton) HandleMouseLeave() {
+	h.left = true
+}
+
+// newEventTree returns a root with a panel that has a button at (0, 0)-(20, 20).
+func newEventTree() (root, panel, button *View, log *[]string) {
+	log = &[]string{}
+	root = &View{Width: 100, Height: 100, Handler: &eventRecorder{name: "root", log: log}}
+	panel = &View{Width: 100, Height: 100, Handler: &eventRecorder{name: "panel", log: log}}
+	button = &View{Width: 20, Height: 20, Handler: &eventButton{eventRecorder: eventRecorder{name: "button", log: log}}}
+	root.AddChild(panel.AddChild(button))
+	root.Update()
+	// Forget the cursor, which is at (0, 0) in tests.
+	root.hovered, root.move = nil, nil
+	*log = nil
+	return root, panel, button, log
+}
+
+func TestEventPropagation(t *testing.T) {
+	root, panel, button, log := newEventTree()
+	b := button.Handler.(*eventButton)
+	panel.Handler.(*eventRecorder).capture = func(e *Event) {
+		require.Equal(t, button, e.Target)
+		require.Equal(t, panel, e.CurrentTarget)
+	}
+
+	root.pressPointer(-1, 5, 5)
+	require.Equal(t, []string{
+		"root press capture",
+		"panel press capture",
+		"button press target",
+		"panel press bubble",
+		"root press bubble",
+	}, *log)
+	require.True(t, b.IsPressed, "the button is pressed by default")
+
+	*log = nil
+	root.releasePointer(-1, 5, 5)
+	require.Len(t, *log, 5)
+	require.True(t, b.IsReleased)
+	require.False(t, b.IsCancel)
+
+	*log = nil
+	e := &Event{Type: EventPress, Target: root.hitTest(50, 50)}
+	require.True(t, dispatchEvent(e))
+	require.Equal(t, []string{"root press capture", "panel press target", "root press bubble"}, *log)
+	require.Equal(t, EventPhaseNone, e.Phase)
+	require.Nil(t, e.CurrentTarget)
+}
+
+func TestEventStopPropagation(t *testing.T) {
+	root, panel, button, log := newEventTree()
+	b := button.Handler.(*eventButton)
+
+	b.handle = func(e *Event) { e.StopPropagation() }
+	root.pressPointer(-1, 5, 5)
+	require.Equal(t, []string{"root press capture", "panel press capture", "button press target"}, *log)
+	require.True(t, b.IsPressed, "stopping the propagation doesn't prevent the default")
+	root.releasePointer(-1, 5, 5)
+
+	*log = nil
+	b.Init()
+	panel.Handler.(*eventRecorder).capture = func(e *Event) {
+		e.StopPropagation()
+		e.PreventDefault()
+	}
+	root.pressPointer(-1, 5, 5)
+	require.Equal(t, []string{"root press capture", "panel press capture"}, *log,
+		"the parent intercepts the press of its child")
+	require.False(t, b.IsPressed)
+}
+
+func TestEventDefaultActionPath(t *testing.T) {
+	root, panel, button, log := newEventTree()
+	b := button.Handler.(*eventButton)
+	label := &View{Width: 10, Height: 10, Handler: &eventRecorder{name: "label", log: log}}
+	button.AddChild(label)
+	root.Update()
+	require.Equal(t, label, root.hitTest(5, 5))
+
+	root.pressPointer(-1, 5, 5)
+	require.True(t, b.IsPressed, "the button under the label is pressed")
+	root.releasePointer(-1, 5, 5)
+	require.True(t, b.IsReleased)
+	require.False(t, b.IsCancel)
+
+	b.Init()
+	label.Handler.(*eventRecorder).handle = func(e *Event) { e.StopPropagation() }
+	root.pressPointer(-1, 5, 5)
+	require.False(t, b.IsPressed, "stopping the propagation skips the default of the ancestors")
+
+	label.Handler.(*eventRecorder).handle = nil
+	root.pressPointer(-1, 5, 5)
+	require.True(t, b.IsPressed)
+	panel.Handler.(*eventRecorder).handle = func(e *Event) { e.StopPropagation() }
+	root.releasePointer(-1, 50, 50)
+	require.True(t, b.IsReleased)
+	require.True(t, b.IsCancel, "the release was stopped before reaching the button")
+}
+
+func TestEventPreventRelease(t *testing.T) {
+	root, panel, button, _ := newEventTree()
+	b := button.Handler.(*eventButton)
+
+	root.pressPointer(0, 5, 5)
+	require.True(t, b.IsPressed)
+	panel.Handler.(*eventRecorder).handle = func(e *Event) {
+		if e.Type == EventRelease {
+			e.PreventDefault()
+		}
+	}
+	root.releasePointer(0, 5, 5)
+	require.True(t, b.IsReleased)
+	require.True(t, b.IsCancel, "preventing the release cancels the press")
+}
+
+func TestEventMoveEnterLeave(t *testing.T) {
+	root, panel, button, log := newEventTree()
+	b := button.Handler.(*eventButton)
+
+	root.moveMouse(5, 5)
+	require.Equal(t, []string{
+		"root enter target",
+		"root enter capture",
+		"panel enter target",
+		"root enter capture",
+		"panel enter capture",
+		"button enter target",
+		"root move capture",
+		"panel move capture",
+		"button move target",
+		"panel move bubble",
+		"root move bubble",
+	}, *log)
+	require.True(t, b.IsMouseMoved)
+
+	*log = nil
+	b.Init()
+	root.moveMouse(5, 5)
+	require.Empty(t, *log, "the move isn't dispatched if the mouse didn't move")
+	require.True(t, b.IsMouseMoved, "the mouse handlers are called every tick")
+
+	root.moveMouse(50, 50)
+	require.Equal(t, []string{
+		"root leave capture",
+		"panel leave capture",
+		"button leave target",
+		"root move capture",
+		"panel move target",
+		"root move bubble",
+	}, *log)
+
+	b.Init()
+	panel.Handler.(*eventRecorder).capture = func(e *Event) { e.PreventDefault() }
+	root.moveMouse(6, 6)
+	require.False(t, b.IsMouseMoved, "the mouse handlers aren't called if the move is prevented")
+	root.moveMouse(6, 6)
+	require.False(t, b.IsMouseMoved, "nor on the next ticks")
+}
+
+func TestEventPreventMoveEnterLeave(t *testing.T) {
+	root, panel, button, log := newEventTree()
+	h := &hoverButton{eventButton: eventButton{eventRecorder: eventRecorder{name: "button", log: log}}}
+	button.Handler = h
+	panel.Handler.(*eventRecorder).capture = func(e *Event) {
+		if e.Type == EventMove {
+			e.PreventDefault()
+		}
+	}
+
+	root.moveMouse(5, 5)
+	require.False(t, h.IsMouseMoved)
+	require.True(t, h.entered, "preventing the move doesn't keep the enter from the handler")
+	root.moveMouse(50, 50)
+	require.True(t, h.left)
+}
+
+func TestEventSwipe(t *testing.T) {
+	root, _, button, log := newEventTree()
+	b := button.Handler.(*eventButton)
+	var dir SwipeDirection
+	b.handle = func(e *Event) {
+		if e.Type == EventSwipe {
+			dir = e.SwipeDirection
+			e.PreventDefault()
+		}
+	}
+
+	root.pressPointer(0, 10, 10)
+	*log = nil
+	root.releasePointer(0, 10, 80)
+	require.Contains(t, *log, "button swipe target", "the swipe goes to the view pressed")
+	require.Equal(t, SwipeDirectionDown, dir)
+	require.False(t, b.IsSwiped, "preventing the swipe keeps it from the SwipeHandler")
+}
+
+func TestHitTest(t *testing.T) {
+	root, panel, button, _ := newEventTree()
+	require.Equal(t, button, root.hitTest(5, 5))
+	require.Equal(t, panel, root.hitTest(50, 50))
+	require.Equal(t, root, root.hitTest(500, 500))
+
+	button.Hidden = true
+	require.Equal(t, panel, root.hitTest(5, 5), "hidden views aren't hit")
+}
+
+func TestHitTestOverlay(t *testing.T) {
+	root, _, button, _ := newEventTree()
+	b := button.Handler.(*eventButton)
+	label := &View{Width: 10, Height: 10}
+	tint := &View{Position: PositionAbsolute, Width: 20, Height: 20,
+		Handler: NewHandler(HandlerOpts{Draw: func(*ebiten.Image, image.Rectangle, *View) {}})}
+	button.AddChild(label, tint)
+	root.Update()
+
+	require.Equal(t, button, root.hitTest(5, 5), "views that don't handle pointer events aren't hit")
+	root.pressPointer(-1, 5, 5)
+	require.True(t, b.IsPressed, "the press goes through the overlays to the button")
+}
@@ -175,8 +175,8 @@ func (g *Game) setupUI() {
 			Width:    g.screen.Width,
 			Height:   g.screen.Height,
 			Position: furex.PositionAbsolute,
-			Left:     0,
-			Top:      0,
+			Left:     Int(0),
+			Top:      Int(0),
 			Handler:  &widgets.Mouse{},
 		},
 	)
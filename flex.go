@@ -13,6 +13,8 @@ type Direction uint8
 const (
 	Row Direction = iota
 	Column
+	RowReverse
+	ColumnReverse
 )
 
 func (d Direction) String() string {
@@ -21,6 +23,10 @@ func (d Direction) String() string {
 		return "row"
 	case Column:
 		return "column"
+	case RowReverse:
+		return "row-reverse"
+	case ColumnReverse:
+		return "column-reverse"
 	default:
 		return fmt.Sprintf("unknown direction: %d", d)
 	}
@@ -35,6 +41,7 @@ const (
 	JustifyCenter                      // pack to center of line
 	JustifySpaceBetween                // even spacing
 	JustifySpaceAround                 // even spacing, half-size on each end
+	JustifySpaceEvenly                 // even spacing, full-size on each end
 )
 
 func (f Justify) String() string {
@@ -49,6 +56,8 @@ func (f Justify) String() string {
 		return "space-between"
 	case JustifySpaceAround:
 		return "space-around"
+	case JustifySpaceEvenly:
+		return "space-evenly"
 	default:
 		return fmt.Sprintf("unknown justify: %d", f)
 	}
@@ -87,6 +96,7 @@ const (
 	AlignItemStart
 	AlignItemEnd
 	AlignItemCenter
+	AlignItemBaseline
 )
 
 func (f AlignItem) String() string {
@@ -99,11 +109,44 @@ func (f AlignItem) String() string {
 		return "flex-end"
 	case AlignItemCenter:
 		return "center"
+	case AlignItemBaseline:
+		return "baseline"
 	default:
 		return fmt.Sprintf("unknown align-item: %d", f)
 	}
 }
 
+// AlignSelf overrides the parent's align-items for a single item.
+type AlignSelf uint8
+
+const (
+	AlignSelfAuto AlignSelf = iota // use the parent's align-items
+	AlignSelfStretch
+	AlignSelfStart
+	AlignSelfEnd
+	AlignSelfCenter
+	AlignSelfBaseline
+)
+
+func (f AlignSelf) String() string {
+	switch f {
+	case AlignSelfAuto:
+		return "auto"
+	case AlignSelfStretch:
+		return "stretch"
+	case AlignSelfStart:
+		return "flex-start"
+	case AlignSelfEnd:
+		return "flex-end"
+	case AlignSelfCenter:
+		return "center"
+	case AlignSelfBaseline:
+		return "baseline"
+	default:
+		return fmt.Sprintf("unknown align-self: %d", f)
+	}
+}
+
 // FlexWrap controls whether the container is single- or multi-line,
 // and the direction in which the lines are laid out.
 type FlexWrap uint8
@@ -138,6 +181,7 @@ const (
 	AlignContentSpaceBetween
 	AlignContentSpaceAround
 	AlignContentStretch
+	AlignContentSpaceEvenly
 )
 
 func (f AlignContent) String() string {
@@ -154,6 +198,8 @@ func (f AlignContent) String() string {
 		return "space-around"
 	case AlignContentStretch:
 		return "stretch"
+	case AlignContentSpaceEvenly:
+		return "space-evenly"
 	}
 	return fmt.Sprintf("unknown align-content: %d", f)
 }
@@ -164,6 +210,7 @@ type Position uint8
 const (
 	PositionStatic Position = iota
 	PositionAbsolute
+	PositionRelative // laid out in flow, then shifted by its insets
 )
 
 func (p Position) String() string {
@@ -172,6 +219,8 @@ func (p Position) String() string {
 		return "static"
 	case PositionAbsolute:
 		return "absolute"
+	case PositionRelative:
+		return "relative"
 	}
 	return fmt.Sprintf("unknown position: %d", p)
 }
@@ -182,6 +231,7 @@ type Display uint8
 const (
 	DisplayFlex Display = iota
 	DisplayNone
+	DisplayGrid
 )
 
 func (d Display) String() string {
@@ -190,10 +240,34 @@ func (d Display) String() string {
 		return "flex"
 	case DisplayNone:
 		return "none"
+	case DisplayGrid:
+		return "grid"
 	}
 	return fmt.Sprintf("unknown display: %d", d)
 }
 
+// Overflow is the 'overflow' property. It tells whether the descendants
+// of a view are clipped to its frame.
+type Overflow uint8
+
+const (
+	OverflowVisible Overflow = iota
+	OverflowHidden
+	OverflowScroll
+)
+
+func (o Overflow) String() string {
+	switch o {
+	case OverflowVisible:
+		return "visible"
+	case OverflowHidden:
+		return "hidden"
+	case OverflowScroll:
+		return "scroll"
+	}
+	return fmt.Sprintf("unknown overflow: %d", o)
+}
+
 type flexEmbed struct {
 	*View
 }
@@ -201,6 +275,17 @@ type flexEmbed struct {
 // layout is the main routine that implements a subset of flexbox layout
 // https://www.w3.org/TR/css-flexbox-1/#layout-algorithm
 func (f *flexEmbed) layout(width, height int, container *containerEmbed) {
+	// Children are laid out inside the content box, while the frame of the
+	// container itself remains the border box.
+	width -= f.PaddingLeft + f.PaddingRight
+	if width < 0 {
+		width = 0
+	}
+	height -= f.PaddingTop + f.PaddingBottom
+	if height < 0 {
+		height = 0
+	}
+
 	// 9.2. Line Length Determination
 	// Determine the available main and cross space for the flex items.
 	containerMainSize := float64(f.mainSize(width, height))
@@ -208,42 +293,29 @@ func (f *flexEmbed) layout(width, height int, container *containerEmbed) {
 
 	// Determine the flex base size and hypothetical main size of each item:
 	var children []element
-	for _, c := range container.children {
+	for _, c := range container.orderedChildren() {
 		if c.item.Display == DisplayNone {
 			continue
 		}
 		if c.item.Position == PositionAbsolute {
-			x := container.frame.Min.X
-			if c.item.Left != 0 {
-				x = container.frame.Min.X + c.item.Left
-			} else if c.item.Right != nil {
-				x = container.frame.Max.X - *c.item.Right - c.item.Width
-			}
-			y := container.frame.Min.Y
-			if c.item.Top != 0 {
-				y = container.frame.Min.Y + c.item.Top
-			} else if c.item.Bottom != nil {
-				y = container.frame.Max.Y - *c.item.Bottom - c.item.Height
-			}
-			c.bounds = image.Rect(x, y, x+c.item.Width, y+c.item.Height)
-			c.item.frame = c.bounds
-			c.absolute = true
+			f.layoutAbsolute(c, container)
 			continue
 		}
 		c.absolute = false
+		c.item.measure(width, height)
 		children = append(children, element{
 			widthInPct:   c.item.WidthInPct,
 			heightInPct:  c.item.HeightInPct,
-			flexBaseSize: float64(f.flexBaseSize(c)),
+			flexBaseSize: float64(f.flexBaseSize(c, width, height)),
 			node:         c,
 		})
 	}
 
 	// Depending on the flex container direction, apply calculation for width and height in percent.
 	switch f.Direction {
-	case Row:
+	case Row, RowReverse:
 		// Calculate the remaining width after taking out the fixed width items.
-		remFree := width
+		remFree := width - int(gapSize(float64(f.ColumnGap), len(children)))
 		for _, c := range children {
 			remFree -= (c.node.item.Width + c.node.item.MarginLeft + c.node.item.MarginRight)
 		}
@@ -253,7 +325,7 @@ func (f *flexEmbed) layout(width, height int, container *containerEmbed) {
 				if c.widthInPct > 0 {
 					v := float64(width) * c.widthInPct / 100.
 					children[i].node.item.calculatedWidth = int(math.Min(v, float64(remFree)))
-					children[i].flexBaseSize = float64(f.flexBaseSize(children[i].node))
+					children[i].flexBaseSize = float64(f.flexBaseSize(children[i].node, width, height))
 				}
 			}
 		}
@@ -264,9 +336,9 @@ func (f *flexEmbed) layout(width, height int, container *containerEmbed) {
 				c.node.item.calculatedHeight = int(float64(height) * c.node.item.HeightInPct / 100)
 			}
 		}
-	case Column:
+	case Column, ColumnReverse:
 		// Calculate the remaining height after taking out the fixed width items.
-		remFree := height
+		remFree := height - int(gapSize(float64(f.RowGap), len(children)))
 		for _, c := range children {
 			remFree -= (c.node.item.Height + c.node.item.MarginTop + c.node.item.MarginBottom)
 		}
@@ -276,7 +348,7 @@ func (f *flexEmbed) layout(width, height int, container *containerEmbed) {
 				if c.heightInPct > 0 {
 					v := float64(height) * c.heightInPct / 100.
 					children[i].node.item.calculatedHeight = int(math.Min(v, float64(remFree)))
-					children[i].flexBaseSize = float64(f.flexBaseSize(children[i].node))
+					children[i].flexBaseSize = float64(f.flexBaseSize(children[i].node, width, height))
 				}
 			}
 		}
@@ -291,8 +363,17 @@ func (f *flexEmbed) layout(width, height int, container *containerEmbed) {
 		panic(fmt.Sprint("flex: bad direction ", f.Direction))
 	}
 
+	// The flex base size of items with an aspect ratio may depend on
+	// the cross size in percent, which is only resolved above.
+	for i, c := range children {
+		if c.node.item.AspectRatio > 0 {
+			children[i].flexBaseSize = float64(f.flexBaseSize(c.node, width, height))
+		}
+	}
+
 	// §9.3. Main Size Determination
 	// Collect flex items into flex lines
+	mainGap, crossGap := f.mainGap(), f.crossGap()
 	var lines []flexLine
 	if f.Wrap == NoWrap {
 		// Single line
@@ -300,8 +381,13 @@ func (f *flexEmbed) layout(width, height int, container *containerEmbed) {
 		for i := range children {
 			child := &children[i]
 			child.mainMargin = f.mainMargin(child.node)
+			child.mainAutoMargin = f.mainAutoMargin(child.node)
+			child.hypotheticalMainSize = f.clampMainSize(child.node.item, child.flexBaseSize, width, height)
 			line.child[i] = child
-			line.mainSize += child.flexBaseSize +
+			if i > 0 {
+				line.mainSize += mainGap
+			}
+			line.mainSize += child.hypotheticalMainSize +
 				(child.mainMargin[0] + child.mainMargin[1])
 		}
 		lines = []flexLine{line}
@@ -311,14 +397,21 @@ func (f *flexEmbed) layout(width, height int, container *containerEmbed) {
 		for i := range children {
 			child := &children[i]
 			child.mainMargin = f.mainMargin(child.node)
+			child.mainAutoMargin = f.mainAutoMargin(child.node)
+			child.hypotheticalMainSize = f.clampMainSize(child.node.item, child.flexBaseSize, width, height)
 
-			// hypotheticalMainSize = flexBaseSize + main margin
-			hypotheticalMainSize := child.flexBaseSize +
+			// hypotheticalMainSize = clamped flexBaseSize + main margin
+			hypotheticalMainSize := child.hypotheticalMainSize +
 				(child.mainMargin[0] + child.mainMargin[1])
 
+			if len(line.child) > 0 {
+				// Gaps are only inserted between items on the same line.
+				hypotheticalMainSize += mainGap
+			}
 			if line.mainSize > 0 && line.mainSize+hypotheticalMainSize > containerMainSize {
 				lines = append(lines, line)
 				line = flexLine{}
+				hypotheticalMainSize -= mainGap
 			}
 			line.child = append(line.child, child)
 			line.mainSize += hypotheticalMainSize
@@ -337,25 +430,29 @@ func (f *flexEmbed) layout(width, height int, container *containerEmbed) {
 
 		// §9.7.2 freeze inflexible children.
 		for _, child := range line.child {
-			mainSize := float64(f.mainSize(child.node.item.width(), child.node.item.height()))
+			child.frozen = false
 			if grow {
-				if child.node.item.Grow == 0 {
+				if child.node.item.Grow == 0 || child.flexBaseSize > child.hypotheticalMainSize {
 					child.frozen = true
-					child.mainSize = mainSize
+					child.mainSize = child.hypotheticalMainSize
 				}
 			} else {
-				if child.node.item.Shrink == 0 {
+				if child.node.item.Shrink == 0 || child.flexBaseSize < child.hypotheticalMainSize {
 					child.frozen = true
-					child.mainSize = mainSize
+					child.mainSize = child.hypotheticalMainSize
 				}
 			}
 		}
 
 		// §9.7.3 calculate initial free space
-		freeSpace := float64(f.mainSize(width, height))
+		gaps := gapSize(mainGap, len(line.child))
+		freeSpace := float64(f.mainSize(width, height)) - gaps
 		for _, child := range line.child {
-			freeSpace -= (float64(f.flexBaseSize(child.node)) +
-				(child.mainMargin[0] + child.mainMargin[1]))
+			size := child.flexBaseSize
+			if child.frozen {
+				size = child.mainSize
+			}
+			freeSpace -= size + (child.mainMargin[0] + child.mainMargin[1])
 		}
 
 		// §9.7.4 flex loop
@@ -373,14 +470,14 @@ func (f *flexEmbed) layout(width, height int, container *containerEmbed) {
 			}
 
 			// Calculate remaining free space.
-			remFreeSpace := float64(f.mainSize(width, height))
+			remFreeSpace := float64(f.mainSize(width, height)) - gaps
 			unfrozenFlexFactor := 0.0
 			for _, child := range line.child {
 				mainMargin := child.mainMargin[0] + child.mainMargin[1]
 				if child.frozen {
 					remFreeSpace -= (child.mainSize + mainMargin)
 				} else {
-					remFreeSpace -= (float64(f.mainSize(child.node.item.width(), child.node.item.height())) + mainMargin)
+					remFreeSpace -= (child.flexBaseSize + mainMargin)
 					if grow {
 						unfrozenFlexFactor += child.node.item.Grow
 					} else {
@@ -403,9 +500,7 @@ func (f *flexEmbed) layout(width, height int, container *containerEmbed) {
 						continue
 					}
 					r := child.node.item.Grow / unfrozenFlexFactor
-					child.mainSize = float64(f.mainSize(
-						child.node.item.width(), child.node.item.height(),
-					)) + r*remFreeSpace
+					child.mainSize = child.flexBaseSize + r*remFreeSpace
 				}
 			} else {
 				sumScaledShrinkFactor := 0.0
@@ -413,29 +508,46 @@ func (f *flexEmbed) layout(width, height int, container *containerEmbed) {
 					if child.frozen {
 						continue
 					}
-					scaledShrinkFactor := float64(f.mainSize(
-						child.node.item.width(), child.node.item.height(),
-					)) * child.node.item.Shrink
-					sumScaledShrinkFactor += scaledShrinkFactor
+					sumScaledShrinkFactor += child.flexBaseSize * child.node.item.Shrink
 				}
 				for _, child := range line.child {
 					if child.frozen {
 						continue
 					}
-					scaledShrinkFactor := float64(f.mainSize(
-						child.node.item.width(), child.node.item.height(),
-					)) * child.node.item.Shrink
-					r := float64(scaledShrinkFactor) / sumScaledShrinkFactor
-					child.mainSize = float64(f.mainSize(
-						child.node.item.width(), child.node.item.height(),
-					)) - r*math.Abs(float64(remFreeSpace))
+					r := 0.0
+					if sumScaledShrinkFactor > 0 {
+						r = child.flexBaseSize * child.node.item.Shrink / sumScaledShrinkFactor
+					}
+					child.mainSize = child.flexBaseSize - r*math.Abs(remFreeSpace)
 				}
 			}
 
+			// Fix min/max violations.
+			totalViolation := 0.0
 			for _, child := range line.child {
-				child.frozen = true
+				if child.frozen {
+					continue
+				}
+				clamped := f.clampMainSize(child.node.item, child.mainSize, width, height)
+				child.violation = clamped - child.mainSize
+				totalViolation += child.violation
+				child.mainSize = clamped
 			}
 
+			// Freeze over-flexed items.
+			for _, child := range line.child {
+				if child.frozen {
+					continue
+				}
+				switch {
+				case totalViolation == 0:
+					child.frozen = true
+				case totalViolation > 0:
+					child.frozen = child.violation > 0
+				case totalViolation < 0:
+					child.frozen = child.violation < 0
+				}
+			}
 		}
 	}
 
@@ -444,12 +556,33 @@ func (f *flexEmbed) layout(width, height int, container *containerEmbed) {
 	for l := range lines {
 		for _, c := range lines[l].child {
 			c.crossMargin = f.crossMargin(c.node)
-			c.crossSize = float64(
+			c.crossAutoMargin = f.crossAutoMargin(c.node)
+			c.crossSize = f.clampCrossSize(c.node.item, float64(
 				f.crossSize(c.node.item.width(), c.node.item.height()),
-			)
+			), width, height)
+			if c.node.item.AspectRatio > 0 && !f.isCrossSizeFixed(c.node.item) {
+				// Keep the ratio with the resolved main size.
+				c.crossSize = f.clampCrossSize(c.node.item,
+					f.crossFromMain(c.node.item, c.mainSize), width, height)
+			}
+			if f.isBaselineAligned(c) {
+				// The distance from the outer cross-start edge to the baseline.
+				b := float64(c.node.item.baseline(round(c.mainSize), round(c.crossSize)))
+				if f.isCrossReversed() {
+					b = c.crossSize - b
+				}
+				c.baseline = c.crossMargin[0] + b
+			}
 		}
 	}
 
+	// The intrinsic cross size of the container is the size of its lines
+	// sized to their items, which doesn't depend on the size of the container.
+	intrinsicCrossSize := gapSize(crossGap, len(lines))
+	for l := range lines {
+		intrinsicCrossSize += f.lineContentCrossSize(&lines[l])
+	}
+
 	// §9.4.8 Calculate the cross size of each flex line.
 	if len(lines) == 1 {
 		// Single line
@@ -458,20 +591,16 @@ func (f *flexEmbed) layout(width, height int, container *containerEmbed) {
 		// Multi line
 		for l := range lines {
 			line := &lines[l]
-			max := 0.0
-			for _, child := range line.child {
-				if child.crossSize > max {
-					max = child.crossSize +
-						(child.crossMargin[0] + child.crossMargin[1])
-				}
-			}
-			line.crossSize = max
+			line.crossSize = f.lineContentCrossSize(line)
 		}
 	}
 
 	off := 0.0
 	for l := range lines {
 		line := &lines[l]
+		if l > 0 {
+			off += crossGap
+		}
 		line.crossOffset = off
 		off += line.crossSize
 	}
@@ -491,11 +620,13 @@ func (f *flexEmbed) layout(width, height int, container *containerEmbed) {
 	for l := range lines {
 		line := &lines[l]
 		for _, child := range line.child {
-			if f.AlignItems == AlignItemStretch &&
+			if f.alignItem(child.node.item) == AlignItemStretch &&
 				!f.isCrossSizeFixed(child.node.item) &&
+				child.node.item.AspectRatio == 0 &&
+				!child.crossAutoMargin[0] && !child.crossAutoMargin[1] &&
 				child.crossSize < line.crossSize {
 				crossMargin := child.crossMargin[0] + child.crossMargin[1]
-				child.crossSize = line.crossSize - crossMargin
+				child.crossSize = f.clampCrossSize(child.node.item, line.crossSize-crossMargin, width, height)
 			}
 		}
 	}
@@ -503,28 +634,67 @@ func (f *flexEmbed) layout(width, height int, container *containerEmbed) {
 	// §9.5. Main-Axis Alignment
 	for l := range lines {
 		line := &lines[l]
-		total := 0.0
+		total := gapSize(mainGap, len(line.child))
 		for _, child := range line.child {
 			total += child.mainSize +
 				(child.mainMargin[0] + child.mainMargin[1])
 		}
 		remFree := containerMainSize - total
+
+		// §9.5.12 distribute positive free space to auto margins.
+		// If there is any, justify-content has no effect.
+		autoMargins := 0
+		for _, child := range line.child {
+			for _, auto := range child.mainAutoMargin {
+				if auto {
+					autoMargins++
+				}
+			}
+		}
+		if autoMargins > 0 && remFree > 0 {
+			size := remFree / float64(autoMargins)
+			for _, child := range line.child {
+				for i, auto := range child.mainAutoMargin {
+					if auto {
+						child.mainMargin[i] = size
+					}
+				}
+			}
+			remFree = 0
+		}
+
 		off, spacing := 0.0, 0.0
+		n := float64(len(line.child))
 		switch f.Justify {
 		case JustifyStart:
 		case JustifyEnd:
 			off = remFree
 		case JustifyCenter:
 			off = remFree / 2
+		// The distributed alignments fall back to flex-start or center
+		// when there is no free space to distribute.
 		case JustifySpaceBetween:
-			spacing = remFree / float64(len(line.child)-1)
+			if remFree > 0 && n > 1 {
+				spacing = remFree / (n - 1)
+			}
 		case JustifySpaceAround:
-			spacing = remFree / float64(len(line.child))
-			off = spacing / 2
+			if remFree > 0 {
+				spacing = remFree / n
+				off = spacing / 2
+			} else {
+				off = remFree / 2
+			}
+		case JustifySpaceEvenly:
+			if remFree > 0 {
+				spacing = remFree / (n + 1)
+				off = spacing
+			} else {
+				off = remFree / 2
+			}
 		}
 		for _, child := range line.child {
 			child.mainOffset = off + (child.mainMargin[0])
-			off += spacing + child.mainSize +
+			off += spacing + mainGap + child.mainSize +
 				(child.mainMargin[0] + child.mainMargin[1])
 		}
 	}
@@ -532,14 +702,38 @@ func (f *flexEmbed) layout(width, height int, container *containerEmbed) {
 	// §9.6. Cross axis alignment
 	for l := range lines {
 		line := &lines[l]
+		ascent, _ := f.baselineExtent(line)
 		for _, child := range line.child {
+			// §9.6.13 resolve cross-axis auto margins.
+			if child.crossAutoMargin[0] || child.crossAutoMargin[1] {
+				free := line.crossSize - child.crossSize -
+					(child.crossMargin[0] + child.crossMargin[1])
+				if free > 0 {
+					if child.crossAutoMargin[0] && child.crossAutoMargin[1] {
+						child.crossMargin[0] += free / 2
+						child.crossMargin[1] += free / 2
+					} else if child.crossAutoMargin[0] {
+						child.crossMargin[0] += free
+					} else {
+						child.crossMargin[1] += free
+					}
+				}
+				child.crossOffset = line.crossOffset + (child.crossMargin[0])
+				continue
+			}
+			if f.isBaselineAligned(child) {
+				// §8.5 align the baselines of the items.
+				child.crossOffset = line.crossOffset + (ascent - child.baseline) +
+					(child.crossMargin[0])
+				continue
+			}
 			child.crossOffset = line.crossOffset + (child.crossMargin[0])
 			if child.crossSize == line.crossSize {
 				continue
 			}
 			diff := line.crossSize - child.crossSize -
 				(child.crossMargin[0] + child.crossMargin[1])
-			switch f.AlignItems {
+			switch f.alignItem(child.node.item) {
 			case AlignItemStart:
 				// already laid out correctly
 			case AlignItemEnd:
@@ -567,10 +761,15 @@ func (f *flexEmbed) layout(width, height int, container *containerEmbed) {
 		case AlignContentCenter:
 			off = remFree / 2
 		case AlignContentSpaceBetween:
-			spacing = remFree / float64(len(lines)-1)
+			if len(lines) > 1 {
+				spacing = remFree / float64(len(lines)-1)
+			}
 		case AlignContentSpaceAround:
 			spacing = remFree / float64(len(lines))
 			off = spacing / 2
+		case AlignContentSpaceEvenly:
+			spacing = remFree / float64(len(lines)+1)
+			off = spacing
 		}
 		if f.AlignContent != AlignContentStart {
 			for l := range lines {
@@ -591,8 +790,11 @@ func (f *flexEmbed) layout(width, height int, container *containerEmbed) {
 		largestMaxContentFlexFraction := -math.MaxFloat64
 		for _, child := range line.child {
 			// 1. Calculate the max-content flex fraction for each item.
+			// The max-content size is the hypothetical main size, rather
+			// than the size flexed into the container, so that the intrinsic
+			// size doesn't depend on the size of the container.
 			flexBaseSize := child.flexBaseSize
-			maxContentSize := child.mainSize
+			maxContentSize := child.hypotheticalMainSize
 			var maxContentFlexFraction float64
 			if maxContentSize > flexBaseSize {
 				// Positive free space, divide by flex grow factor (floored at 1).
@@ -610,6 +812,8 @@ func (f *flexEmbed) layout(width, height int, container *containerEmbed) {
 		}
 
 		// 2. Add each item’s flex base size to the product of its flex grow/shrink factor and the largest max-content flex fraction.
+		// 3. Determine line size and update intrinsicMainSize.
+		lineSize := gapSize(mainGap, len(line.child))
 		for _, child := range line.child {
 			var newMainSize float64
 			if largestMaxContentFlexFraction > 0 {
@@ -617,45 +821,21 @@ func (f *flexEmbed) layout(width, height int, container *containerEmbed) {
 			} else {
 				newMainSize = child.flexBaseSize - (child.node.item.Shrink * child.flexBaseSize * largestMaxContentFlexFraction)
 			}
-			child.mainSize = newMainSize
-		}
-
-		// 3. Determine line size and update intrinsicMainSize.
-		lineSize := 0.0
-		for _, child := range line.child {
-			lineSize += child.mainSize
+			lineSize += f.clampMainSize(child.node.item, newMainSize, width, height)
 		}
 		if lineSize > intrinsicMainSize {
 			intrinsicMainSize = lineSize
 		}
 	}
-	f.setMainSize(int(intrinsicMainSize))
+	f.setMainSize(int(intrinsicMainSize) + f.mainSize(
+		f.PaddingLeft+f.PaddingRight, f.PaddingTop+f.PaddingBottom))
 
 	// §9.9.2. Flex Container Intrinsic Cross Sizes
 	// The min-content/max-content cross size of a single-line flex container
 	// is the largest min-content contribution/max-content contribution (respectively)
-	// of its flex items.
-	intrinsicCrossSize := 0.0
-	for _, line := range lines {
-		if intrinsicCrossSize < line.crossOffset+line.crossSize {
-			intrinsicCrossSize = line.crossOffset + line.crossSize
-		}
-
-		min := math.Inf(1)
-		max := -1.
-		for _, child := range line.child {
-			if child.crossOffset < min {
-				min = child.crossOffset
-			}
-			if max == -1 || child.crossOffset+child.crossSize > max {
-				max = child.crossOffset + child.crossSize
-			}
-		}
-		if intrinsicCrossSize < max-min {
-			intrinsicCrossSize = max - min
-		}
-	}
-	f.setCrossSize(int(intrinsicCrossSize))
+	// of its flex items. It was computed with the cross size of the lines.
+	f.setCrossSize(int(intrinsicCrossSize) + f.crossSize(
+		f.PaddingLeft+f.PaddingRight, f.PaddingTop+f.PaddingBottom))
 
 	// TODO: Calculate min-content/max-content cross size for multi-line flex container.
 	// For a multi-line flex container, the min-content/max-content cross size is
@@ -666,42 +846,160 @@ func (f *flexEmbed) layout(width, height int, container *containerEmbed) {
 	// among the flex items (respectively), then using that size as the available
 	// space in the cross axis for each of the flex items during layout.
 
+	// The offsets so far are computed from main-start and cross-start.
+	// Flip them for reversed directions and wrap-reverse.
+	for l := range lines {
+		line := &lines[l]
+		for _, child := range line.child {
+			if f.isMainReversed() {
+				child.mainOffset = containerMainSize - child.mainOffset - child.mainSize
+			}
+			if f.isCrossReversed() {
+				child.crossOffset = containerCrossSize - child.crossOffset - child.crossSize
+			}
+		}
+	}
+
 	// Layout complete. Update children position
+	padding := image.Pt(f.PaddingLeft, f.PaddingTop)
 	for l := range lines {
 		line := &lines[l]
 		for _, child := range line.child {
 			switch f.Direction {
-			case Row:
+			case Row, RowReverse:
 				child.node.bounds = image.Rect(
 					round(child.mainOffset),
 					round(child.crossOffset),
 					round(child.mainOffset+child.mainSize),
-					round(child.crossOffset+child.crossSize))
-				child.node.item.setFrame(child.node.bounds.Add(f.frame.Min))
-			case Column:
+					round(child.crossOffset+child.crossSize)).Add(padding)
+			case Column, ColumnReverse:
 				child.node.bounds = image.Rect(
 					round(child.crossOffset),
 					round(child.mainOffset),
 					round(child.crossOffset+child.crossSize),
-					round(child.mainOffset+child.mainSize))
-				child.node.item.setFrame(child.node.bounds.Add(f.frame.Min))
+					round(child.mainOffset+child.mainSize)).Add(padding)
 			default:
 				panic(fmt.Sprint("flex: bad direction ", f.Direction))
 			}
+			if child.node.item.Position == PositionRelative {
+				// Relatively positioned items are shifted after the layout,
+				// so the other items are not affected.
+				child.node.bounds = child.node.bounds.Add(
+					child.node.item.relativeOffset(width, height))
+			}
+			child.node.item.setFrame(child.node.bounds.Add(f.frame.Min))
+		}
+	}
+
+	f.scrollChildren(container)
+}
+
+// scrollChildren records the size of the content of the container,
+// and moves the children by the scroll offset.
+func (f *flexEmbed) scrollChildren(container *containerEmbed) {
+	var content image.Point
+	for _, c := range container.children {
+		if c.item.Display == DisplayNone {
+			continue
+		}
+		b := c.bounds
+		if c.absolute {
+			b = b.Sub(container.frame.Min)
+		}
+		if b.Max.X > content.X {
+			content.X = b.Max.X
+		}
+		if b.Max.Y > content.Y {
+			content.Y = b.Max.Y
+		}
+	}
+	f.scroll.contentSize = content.Add(image.Pt(f.PaddingRight, f.PaddingBottom))
+
+	offset := f.ScrollOffset()
+	if offset == (image.Point{}) {
+		return
+	}
+	for _, c := range container.children {
+		if c.item.Display == DisplayNone {
+			continue
 		}
+		c.bounds = c.bounds.Sub(offset)
+		c.item.setFrame(*container.childFrame(c))
+	}
+}
+
+// layoutAbsolute positions an absolutely positioned child against the frame
+// of the container, which is its containing block. Insets and sizes in
+// percent are resolved against the size of the frame. When the size is not
+// set, the child is stretched between its insets if both are set, or else
+// sized to its content.
+func (f *flexEmbed) layoutAbsolute(c *child, container *containerEmbed) {
+	v := c.item
+	cb := container.frame
+	v.measure(cb.Dx(), cb.Dy())
+
+	left, hasLeft := resolveInset(v.Left, v.LeftInPct, cb.Dx())
+	right, hasRight := resolveInset(v.Right, v.RightInPct, cb.Dx())
+	top, hasTop := resolveInset(v.Top, v.TopInPct, cb.Dy())
+	bottom, hasBottom := resolveInset(v.Bottom, v.BottomInPct, cb.Dy())
+
+	w, hasWidth := resolveLength(v.Width, v.WidthInPct, cb.Dx()), v.isWidthFixed()
+	if !hasWidth && hasLeft && hasRight {
+		w, hasWidth = float64(cb.Dx())-left-right, true
+	}
+	h, hasHeight := resolveLength(v.Height, v.HeightInPct, cb.Dy()), v.isHeightFixed()
+	if !hasHeight && hasTop && hasBottom {
+		h, hasHeight = float64(cb.Dy())-top-bottom, true
 	}
+	if v.AspectRatio > 0 {
+		if hasWidth && !hasHeight {
+			h, hasHeight = w/v.AspectRatio, true
+		} else if hasHeight && !hasWidth {
+			w, hasWidth = h*v.AspectRatio, true
+		}
+	}
+	if !hasWidth {
+		w = float64(v.calculatedWidth)
+	}
+	if !hasHeight {
+		h = float64(v.calculatedHeight)
+	}
+	w = math.Max(0, v.clampWidth(w, cb.Dx()))
+	h = math.Max(0, v.clampHeight(h, cb.Dy()))
+
+	x := float64(cb.Min.X)
+	if hasLeft {
+		x += left
+	} else if hasRight {
+		x = float64(cb.Max.X) - right - w
+	}
+	y := float64(cb.Min.Y)
+	if hasTop {
+		y += top
+	} else if hasBottom {
+		y = float64(cb.Max.Y) - bottom - h
+	}
+
+	c.bounds = image.Rect(round(x), round(y), round(x+w), round(y+h))
+	c.absolute = true
+	v.setFrame(c.bounds)
 }
 
 type element struct {
 	node                   *child
+	baseline               float64
 	flexBaseSize           float64
 	mainSize               float64
 	mainOffset             float64
 	mainMargin             []float64
+	mainAutoMargin         [2]bool
 	crossSize              float64
 	crossOffset            float64
 	crossMargin            []float64
+	crossAutoMargin        [2]bool
+	hypotheticalMainSize   float64
 	frozen                 bool
+	violation              float64
 	maxContentFlexFraction float64
 	widthInPct             float64
 	heightInPct            float64
@@ -716,20 +1014,38 @@ type flexLine struct {
 
 func (f *flexEmbed) mainSize(x, y int) int {
 	switch f.Direction {
-	case Row:
+	case Row, RowReverse:
 		return x
-	case Column:
+	case Column, ColumnReverse:
 		return y
 	default:
 		panic(fmt.Sprint("flex: bad direction ", f.Direction))
 	}
 }
 
+// lineContentCrossSize returns the cross size of the line sized to its
+// items: the largest outer cross size of them.
+func (f *flexEmbed) lineContentCrossSize(line *flexLine) float64 {
+	max := 0.0
+	for _, child := range line.child {
+		outer := child.crossSize + child.crossMargin[0] + child.crossMargin[1]
+		if outer > max {
+			max = outer
+		}
+	}
+	// Baseline-aligned items need the space above the
+	// largest baseline plus the space below the largest one.
+	if ascent, descent := f.baselineExtent(line); ascent+descent > max {
+		max = ascent + descent
+	}
+	return max
+}
+
 func (f *flexEmbed) setCrossSize(v int) {
 	switch f.Direction {
-	case Row:
+	case Row, RowReverse:
 		f.calculatedHeight = v
-	case Column:
+	case Column, ColumnReverse:
 		f.calculatedWidth = v
 	default:
 		panic(fmt.Sprint("flex: bad direction ", f.Direction))
@@ -738,9 +1054,9 @@ func (f *flexEmbed) setCrossSize(v int) {
 
 func (f *flexEmbed) setMainSize(v int) {
 	switch f.Direction {
-	case Row:
+	case Row, RowReverse:
 		f.calculatedWidth = v
-	case Column:
+	case Column, ColumnReverse:
 		f.calculatedHeight = v
 	default:
 		panic(fmt.Sprint("flex: bad direction ", f.Direction))
@@ -749,57 +1065,210 @@ func (f *flexEmbed) setMainSize(v int) {
 
 func (f *flexEmbed) isCrossSizeFixed(v *View) bool {
 	switch f.Direction {
-	case Row:
+	case Row, RowReverse:
 		return v.isHeightFixed()
-	case Column:
+	case Column, ColumnReverse:
 		return v.isWidthFixed()
 	default:
 		panic(fmt.Sprint("flex: bad direction ", f.Direction))
 	}
 }
 
+func (f *flexEmbed) isMainSizeFixed(v *View) bool {
+	switch f.Direction {
+	case Row, RowReverse:
+		return v.isWidthFixed()
+	case Column, ColumnReverse:
+		return v.isHeightFixed()
+	default:
+		panic(fmt.Sprint("flex: bad direction ", f.Direction))
+	}
+}
+
 func (f *flexEmbed) crossSize(x, y int) int {
 	switch f.Direction {
-	case Row:
+	case Row, RowReverse:
 		return y
-	case Column:
+	case Column, ColumnReverse:
 		return x
 	default:
 		panic(fmt.Sprint("flex: bad direction ", f.Direction))
 	}
 }
 
+// mainMargin returns the main-start and main-end margins of the item.
+// Auto margins are treated as zero.
 func (f *flexEmbed) mainMargin(c *child) []float64 {
-	switch f.Direction {
+	l, t, r, b := c.item.margins()
+	m := mainSides(f.Direction, l, t, r, b)
+	return []float64{m[0], m[1]}
+}
+
+// crossMargin returns the cross-start and cross-end margins of the item.
+// Auto margins are treated as zero.
+func (f *flexEmbed) crossMargin(c *child) []float64 {
+	l, t, r, b := c.item.margins()
+	m := crossSides(f.Direction, f.Wrap, l, t, r, b)
+	return []float64{m[0], m[1]}
+}
+
+// mainAutoMargin reports whether the main-start and main-end margins are auto.
+func (f *flexEmbed) mainAutoMargin(c *child) [2]bool {
+	v := c.item
+	return mainSides(f.Direction,
+		v.MarginLeftAuto, v.MarginTopAuto, v.MarginRightAuto, v.MarginBottomAuto)
+}
+
+// crossAutoMargin reports whether the cross-start and cross-end margins are auto.
+func (f *flexEmbed) crossAutoMargin(c *child) [2]bool {
+	v := c.item
+	return crossSides(f.Direction, f.Wrap,
+		v.MarginLeftAuto, v.MarginTopAuto, v.MarginRightAuto, v.MarginBottomAuto)
+}
+
+// mainSides picks the values of the main-start and main-end sides
+// out of the values of the left, top, right and bottom sides.
+func mainSides[T any](d Direction, left, top, right, bottom T) [2]T {
+	switch d {
 	case Row:
-		return []float64{
-			float64(c.item.MarginLeft),
-			float64(c.item.MarginRight)}
+		return [2]T{left, right}
 	case Column:
-		return []float64{
-			float64(c.item.MarginTop),
-			float64(c.item.MarginBottom)}
+		return [2]T{top, bottom}
+	case RowReverse:
+		return [2]T{right, left}
+	case ColumnReverse:
+		return [2]T{bottom, top}
 	default:
 		panic("unreachable")
 	}
 }
 
-func (f *flexEmbed) crossMargin(c *child) []float64 {
+// crossSides picks the values of the cross-start and cross-end sides
+// out of the values of the left, top, right and bottom sides.
+func crossSides[T any](d Direction, w FlexWrap, left, top, right, bottom T) [2]T {
+	var m [2]T
+	switch d {
+	case Row, RowReverse:
+		m = [2]T{top, bottom}
+	case Column, ColumnReverse:
+		m = [2]T{left, right}
+	default:
+		panic("unreachable")
+	}
+	if w == WrapReverse {
+		m[0], m[1] = m[1], m[0]
+	}
+	return m
+}
+
+// isMainReversed reports whether main-start is on the right or bottom side.
+func (f *flexEmbed) isMainReversed() bool {
+	return f.Direction == RowReverse || f.Direction == ColumnReverse
+}
+
+// isCrossReversed reports whether cross-start is on the right or bottom side.
+func (f *flexEmbed) isCrossReversed() bool {
+	return f.Wrap == WrapReverse
+}
+
+// alignItem returns the cross axis alignment of the item v,
+// resolving 'align-self: auto' to the container's align-items.
+// Baseline alignment falls back to flex-start in columns, where the
+// cross axis is not parallel to the baselines.
+func (f *flexEmbed) alignItem(v *View) AlignItem {
+	align := f.AlignItems
+	switch v.AlignSelf {
+	case AlignSelfStretch:
+		align = AlignItemStretch
+	case AlignSelfStart:
+		align = AlignItemStart
+	case AlignSelfEnd:
+		align = AlignItemEnd
+	case AlignSelfCenter:
+		align = AlignItemCenter
+	case AlignSelfBaseline:
+		align = AlignItemBaseline
+	}
+	if align == AlignItemBaseline && (f.Direction == Column || f.Direction == ColumnReverse) {
+		return AlignItemStart
+	}
+	return align
+}
+
+// isBaselineAligned reports whether the item participates in
+// baseline alignment, which items with cross auto margins don't.
+func (f *flexEmbed) isBaselineAligned(c *element) bool {
+	return f.alignItem(c.node.item) == AlignItemBaseline &&
+		!c.crossAutoMargin[0] && !c.crossAutoMargin[1]
+}
+
+// baselineExtent returns the largest distance between the baseline and
+// the outer cross-start edge, and the largest distance between the
+// baseline and the outer cross-end edge, of the baseline-aligned items
+// on the line.
+func (f *flexEmbed) baselineExtent(line *flexLine) (ascent, descent float64) {
+	for _, child := range line.child {
+		if !f.isBaselineAligned(child) {
+			continue
+		}
+		if child.baseline > ascent {
+			ascent = child.baseline
+		}
+		d := child.crossSize + (child.crossMargin[0] + child.crossMargin[1]) - child.baseline
+		if d > descent {
+			descent = d
+		}
+	}
+	return ascent, descent
+}
+
+func (f *flexEmbed) mainGap() float64 {
 	switch f.Direction {
-	case Row:
-		return []float64{
-			float64(c.item.MarginTop),
-			float64(c.item.MarginBottom)}
-	case Column:
-		return []float64{
-			float64(c.item.MarginLeft),
-			float64(c.item.MarginRight)}
+	case Row, RowReverse:
+		return float64(f.ColumnGap)
+	case Column, ColumnReverse:
+		return float64(f.RowGap)
+	default:
+		panic("unreachable")
+	}
+}
+
+func (f *flexEmbed) crossGap() float64 {
+	switch f.Direction {
+	case Row, RowReverse:
+		return float64(f.RowGap)
+	case Column, ColumnReverse:
+		return float64(f.ColumnGap)
 	default:
 		panic("unreachable")
 	}
 }
 
-func (f *flexEmbed) flexBaseSize(c *child) int {
+// gapSize returns the total size of the gaps between n items.
+func gapSize(gap float64, n int) float64 {
+	if n < 2 {
+		return 0
+	}
+	return gap * float64(n-1)
+}
+
+// flexBaseSize determines the flex base size of the item (§9.2.3).
+// A definite flex basis is used as is, percentages being resolved
+// against the container's inner main size. If the item has an aspect
+// ratio and a definite cross size but no main size, the main size is
+// derived from the ratio. Otherwise the size of the item is used.
+func (f *flexEmbed) flexBaseSize(c *child, width, height int) int {
+	if c.item.Basis != nil {
+		return *c.item.Basis
+	}
+	if c.item.BasisInPct != 0 {
+		return int(float64(f.mainSize(width, height)) * c.item.BasisInPct / 100)
+	}
+	if c.item.AspectRatio > 0 && !f.isMainSizeFixed(c.item) {
+		if cross := f.definiteCrossSize(c, width, height); cross > 0 {
+			return int(f.mainFromCross(c.item, cross))
+		}
+	}
 	w := c.item.Width
 	if w == 0 {
 		w = c.item.calculatedWidth
@@ -811,15 +1280,73 @@ func (f *flexEmbed) flexBaseSize(c *child) int {
 	return f.mainSize(w, h)
 }
 
-func (f *flexEmbed) clampSize(size, width, height int) int {
-	minSize := f.mainSize(width, height)
-	if minSize > size {
-		size = minSize
+// clampMainSize clamps the main size of v by its min and max main size.
+// The width and height are the size of the container's content box
+// that percentages are resolved against.
+func (f *flexEmbed) clampMainSize(v *View, size float64, width, height int) float64 {
+	switch f.Direction {
+	case Row, RowReverse:
+		return v.clampWidth(size, width)
+	case Column, ColumnReverse:
+		return v.clampHeight(size, height)
+	default:
+		panic(fmt.Sprint("flex: bad direction ", f.Direction))
+	}
+}
+
+// clampCrossSize clamps the cross size of v by its min and max cross size.
+func (f *flexEmbed) clampCrossSize(v *View, size float64, width, height int) float64 {
+	switch f.Direction {
+	case Row, RowReverse:
+		return v.clampHeight(size, height)
+	case Column, ColumnReverse:
+		return v.clampWidth(size, width)
+	default:
+		panic(fmt.Sprint("flex: bad direction ", f.Direction))
+	}
+}
+
+// definiteCrossSize returns the cross size of the item that is known
+// before its main size is resolved, or 0 if there is none. That is
+// its own cross size, or the cross size of the line when the item is
+// stretched in a single-line container (§9.8).
+func (f *flexEmbed) definiteCrossSize(c *child, width, height int) float64 {
+	v := c.item
+	if f.isCrossSizeFixed(v) {
+		return f.clampCrossSize(v, float64(f.crossSize(v.width(), v.height())), width, height)
 	}
-	if size < 0 {
+	auto := f.crossAutoMargin(c)
+	if f.Wrap != NoWrap || f.alignItem(v) != AlignItemStretch || auto[0] || auto[1] {
 		return 0
 	}
-	return size
+	m := f.crossMargin(c)
+	return f.clampCrossSize(v, float64(f.crossSize(width, height))-(m[0]+m[1]), width, height)
+}
+
+// mainFromCross converts the cross size of v to the main size
+// by its aspect ratio.
+func (f *flexEmbed) mainFromCross(v *View, cross float64) float64 {
+	switch f.Direction {
+	case Row, RowReverse:
+		return cross * v.AspectRatio
+	case Column, ColumnReverse:
+		return cross / v.AspectRatio
+	default:
+		panic(fmt.Sprint("flex: bad direction ", f.Direction))
+	}
+}
+
+// crossFromMain converts the main size of v to the cross size
+// by its aspect ratio.
+func (f *flexEmbed) crossFromMain(v *View, main float64) float64 {
+	switch f.Direction {
+	case Row, RowReverse:
+		return main / v.AspectRatio
+	case Column, ColumnReverse:
+		return main * v.AspectRatio
+	default:
+		panic(fmt.Sprint("flex: bad direction ", f.Direction))
+	}
 }
 
 func round(f float64) int {
@@ -118,8 +118,8 @@ func TestAbsolutePos(t *testing.T) {
 	f1 := &View{
 		Width:      100,
 		Height:     200,
-		Left:       left,
-		Top:        top,
+		Left:       Int(left),
+		Top:        Int(top),
 		Position:   PositionAbsolute,
 		Direction:  Row,
 		Justify:    JustifyCenter,
@@ -184,8 +184,8 @@ func TestAbsolutePosNested(t *testing.T) {
 	f2 := &View{
 		Width:      50,
 		Height:     150,
-		Left:       100,
-		Top:        50,
+		Left:       Int(100),
+		Top:        Int(50),
 		Position:   PositionAbsolute,
 		Direction:  Row,
 		Justify:    JustifyCenter,
@@ -228,6 +228,173 @@ func TestAbsolutePosNested(t *testing.T) {
 	require.Equal(t, image.Rect(x, y, x+w, y+h), mock.Frame)
 }
 
+func TestAbsoluteInsets(t *testing.T) {
+	var tests = []struct {
+		name string
+		view *View
+		init func(v *View)
+		want image.Rectangle
+	}{
+		{
+			name: "stretch between left and right",
+			view: &View{Left: Int(10), Right: Int(30), Height: 20},
+			want: image.Rect(10, 0, 170, 20),
+		},
+		{
+			name: "explicit zero top",
+			view: &View{Width: 20, Bottom: Int(10)},
+			init: func(v *View) { v.SetTop(0) },
+			want: image.Rect(0, 0, 20, 90),
+		},
+		{
+			name: "explicit zero top in the struct",
+			view: &View{Width: 20, Top: Int(0), Bottom: Int(10)},
+			want: image.Rect(0, 0, 20, 90),
+		},
+		{
+			name: "unset top",
+			view: &View{Width: 20, Height: 20, Bottom: Int(10)},
+			init: func(v *View) { v.SetTop(0); v.Top = nil },
+			want: image.Rect(0, 70, 20, 90),
+		},
+		{
+			name: "size and insets in percent",
+			view: &View{LeftInPct: 10, TopInPct: 20, WidthInPct: 50, HeightInPct: 50},
+			want: image.Rect(20, 20, 120, 70),
+		},
+		{
+			name: "pixels win over percent",
+			view: &View{Left: Int(10), LeftInPct: 50, Width: 20, Height: 20},
+			want: image.Rect(10, 0, 30, 20),
+		},
+		{
+			name: "right and bottom in percent",
+			view: &View{RightInPct: 10, BottomInPct: 10, Width: 20, Height: 20},
+			want: image.Rect(160, 70, 180, 90),
+		},
+		{
+			name: "stretch with min-width",
+			view: &View{Left: Int(10), Right: Int(180), MinWidth: 30, Height: 10},
+			want: image.Rect(10, 0, 40, 10),
+		},
+		{
+			name: "height from aspect ratio",
+			view: &View{Left: Int(10), Right: Int(90), AspectRatio: 2},
+			want: image.Rect(10, 0, 110, 50),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := mockHandler{}
+			tt.view.Position = PositionAbsolute
+			tt.view.Handler = &mock
+			if tt.init != nil {
+				tt.init(tt.view)
+			}
+			flex := (&View{Width: 200, Height: 100}).AddChild(tt.view)
+
+			flex.Update()
+			flex.Draw(nil)
+
+			assert.Equal(t, tt.want, mock.Frame)
+		})
+	}
+}
+
+func TestAbsoluteContentSize(t *testing.T) {
+	abs := &mockHandler{}
+	item := &mockHandler{}
+	flex := (&View{Width: 200, Height: 100}).AddChild(
+		(&View{
+			Position:   PositionAbsolute,
+			Left:       Int(10),
+			Top:        Int(10),
+			Direction:  Column,
+			AlignItems: AlignItemStart,
+			Handler:    abs,
+		}).AddChild(
+			&View{Width: 30, Height: 40, Handler: item},
+		),
+	)
+
+	flex.Update()
+	flex.Draw(nil)
+
+	assert.Equal(t, image.Rect(10, 10, 40, 50), abs.Frame)
+	assert.Equal(t, image.Rect(10, 10, 40, 50), item.Frame)
+}
+
+func TestRelativePosition(t *testing.T) {
+	var tests = []struct {
+		name string
+		view *View
+		want image.Rectangle
+	}{
+		{
+			name: "left and top",
+			view: &View{Left: Int(10), Top: Int(5)},
+			want: image.Rect(60, 5, 110, 55),
+		},
+		{
+			name: "right and bottom",
+			view: &View{Right: Int(10), Bottom: Int(5)},
+			want: image.Rect(40, -5, 90, 45),
+		},
+		{
+			name: "left wins over right",
+			view: &View{Left: Int(10), Right: Int(20)},
+			want: image.Rect(60, 0, 110, 50),
+		},
+		{
+			name: "percent",
+			view: &View{LeftInPct: 10, TopInPct: 10},
+			want: image.Rect(80, 10, 130, 60),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mocks := [3]mockHandler{}
+			tt.view.Width, tt.view.Height = 50, 50
+			tt.view.Position = PositionRelative
+			tt.view.Handler = &mocks[1]
+			flex := (&View{
+				Width:      300,
+				Height:     100,
+				Direction:  Row,
+				AlignItems: AlignItemStart,
+			}).AddChild(
+				&View{Width: 50, Height: 50, Handler: &mocks[0]},
+				tt.view,
+				&View{Width: 50, Height: 50, Handler: &mocks[2]},
+			)
+
+			flex.Update()
+			flex.Draw(nil)
+
+			// The siblings are laid out as if the view was not shifted.
+			assert.Equal(t, image.Rect(0, 0, 50, 50), mocks[0].Frame)
+			assert.Equal(t, tt.want, mocks[1].Frame)
+			assert.Equal(t, image.Rect(100, 0, 150, 50), mocks[2].Frame)
+		})
+	}
+}
+
+func TestRelativePositionHitTest(t *testing.T) {
+	mock := &mockHandler{}
+	flex := (&View{Width: 300, Height: 100, AlignItems: AlignItemStart}).AddChild(
+		&View{Width: 50, Height: 50, Position: PositionRelative, Left: Int(100), Handler: mock},
+	)
+
+	flex.Update()
+	flex.Draw(nil)
+
+	flex.HandleJustPressedTouchID(0, 10, 10)
+	assert.False(t, mock.IsPressed)
+
+	flex.HandleJustPressedTouchID(0, 110, 10)
+	assert.True(t, mock.IsPressed)
+}
+
 func TestNesting(t *testing.T) {
 	parent := &View{
 		Width:      300,
@@ -235,8 +402,8 @@ func TestNesting(t *testing.T) {
 		Direction:  Column,
 		Justify:    JustifyCenter,
 		AlignItems: AlignItemCenter,
-		Left:       100,
-		Top:        50,
+		Left:       Int(100),
+		Top:        Int(50),
 		Position:   PositionAbsolute,
 	}
 
@@ -888,6 +1055,24 @@ func TestAutoHeightCalculation(t *testing.T) {
 	assert.Equal(t, image.Rect(0, 100, 200, 300), mock2.Frame)
 }
 
+func TestAutoHeightWithMargin(t *testing.T) {
+	flex := &View{Width: 1000, Height: 1000, AlignItems: AlignItemStart, Direction: Column}
+
+	mock := mockHandler{}
+	row := &View{Direction: Row, AlignItems: AlignItemStart, Width: 100, Handler: &mock}
+	row.AddChild(
+		&View{Width: 10, Height: 20},
+		&View{Width: 10, Height: 15, MarginTop: 10},
+	)
+	flex.AddChild(row)
+
+	flex.Update()
+	flex.Draw(nil)
+
+	assert.Equal(t, image.Rect(0, 0, 100, 25), mock.Frame,
+		"the line is as tall as the largest outer height of its items")
+}
+
 func TestWidthInPctRow(t *testing.T) {
 	flex := &View{
 		Width:      500,
@@ -1036,6 +1221,777 @@ func TestShrink(t *testing.T) {
 	assert.Equal(t, image.Pt(w, h*items), mock.Frame.Size())
 }
 
+func TestPadding(t *testing.T) {
+	mocks := [3]mockHandler{}
+
+	flex := &View{
+		Width:         200,
+		Height:        100,
+		PaddingLeft:   10,
+		PaddingTop:    20,
+		PaddingRight:  30,
+		PaddingBottom: 40,
+		Direction:     Row,
+		Justify:       JustifyEnd,
+		AlignItems:    AlignItemStretch,
+		Handler:       &mocks[0],
+	}
+
+	flex.AddChild(
+		&View{Width: 50, Handler: &mocks[1]},
+		&View{Width: 50, Handler: &mocks[2]},
+	)
+
+	flex.Update()
+	flex.Draw(nil)
+
+	// (0,0)
+	// ┌──────────────────────────────────────┐
+	// │ padding                              │
+	// │    (70,20)───────┬──────────┐        │
+	// │         │ item1  │ item2    │        │
+	// │         └──────(120,60)───(170,60)   │
+	// │                                      │
+	// └──────────────────────────────────────┘
+	//                                   (200,100)
+
+	assert.Equal(t, image.Rect(0, 0, 200, 100), mocks[0].Frame)
+	assert.Equal(t, image.Rect(70, 20, 120, 60), mocks[1].Frame)
+	assert.Equal(t, image.Rect(120, 20, 170, 60), mocks[2].Frame)
+}
+
+func TestPaddingAutoSize(t *testing.T) {
+	mock := mockHandler{}
+
+	root := &View{
+		Width:      200,
+		Height:     200,
+		Direction:  Row,
+		AlignItems: AlignItemStart,
+	}
+
+	panel := &View{
+		PaddingLeft:   5,
+		PaddingTop:    5,
+		PaddingRight:  5,
+		PaddingBottom: 5,
+		Handler:       &mock,
+	}
+	panel.AddChild(&View{Width: 40, Height: 30})
+	root.AddChild(panel)
+
+	root.Update()
+	root.Draw(nil)
+	root.Update()
+	root.Draw(nil)
+
+	assert.Equal(t, image.Rect(0, 0, 50, 40), mock.Frame)
+}
+
+func TestGap(t *testing.T) {
+	t.Run("wrap", func(t *testing.T) {
+		flex := &View{
+			Width:      230,
+			Height:     200,
+			Direction:  Row,
+			Wrap:       Wrap,
+			AlignItems: AlignItemStart,
+			RowGap:     20,
+			ColumnGap:  10,
+		}
+
+		mocks := [3]mockHandler{}
+		for i := range mocks {
+			flex.AddChild(&View{Width: 100, Height: 50, Handler: &mocks[i]})
+		}
+
+		flex.Update()
+		flex.Draw(nil)
+
+		assert.Equal(t, image.Rect(0, 0, 100, 50), mocks[0].Frame)
+		assert.Equal(t, image.Rect(110, 0, 210, 50), mocks[1].Frame)
+		assert.Equal(t, image.Rect(0, 70, 100, 120), mocks[2].Frame)
+	})
+
+	t.Run("space-between", func(t *testing.T) {
+		flex := &View{
+			Width:     300,
+			Height:    100,
+			Direction: Row,
+			Justify:   JustifySpaceBetween,
+			ColumnGap: 10,
+		}
+
+		mocks := [2]mockHandler{}
+		for i := range mocks {
+			flex.AddChild(&View{Width: 100, Height: 50, Handler: &mocks[i]})
+		}
+
+		flex.Update()
+		flex.Draw(nil)
+
+		assert.Equal(t, image.Rect(0, 0, 100, 50), mocks[0].Frame)
+		assert.Equal(t, image.Rect(200, 0, 300, 50), mocks[1].Frame)
+	})
+
+	t.Run("grow", func(t *testing.T) {
+		flex := &View{
+			Width:     50,
+			Height:    220,
+			Direction: Column,
+			RowGap:    20,
+		}
+
+		mocks := [2]mockHandler{}
+		for i := range mocks {
+			flex.AddChild(&View{Grow: 1, Handler: &mocks[i]})
+		}
+
+		flex.Update()
+		flex.Draw(nil)
+
+		assert.Equal(t, image.Rect(0, 0, 50, 100), mocks[0].Frame)
+		assert.Equal(t, image.Rect(0, 120, 50, 220), mocks[1].Frame)
+	})
+}
+
+func TestMinMaxSize(t *testing.T) {
+	var tests = []struct {
+		name     string
+		flex     *View
+		children []*View
+		want     []image.Rectangle
+	}{
+		{
+			name:     "grow with max-width",
+			flex:     &View{Width: 600, Height: 100, Direction: Row},
+			children: []*View{{Grow: 1, MaxWidth: 200}, {Grow: 1}},
+			want:     []image.Rectangle{image.Rect(0, 0, 200, 100), image.Rect(200, 0, 600, 100)},
+		},
+		{
+			name:     "shrink with min-width",
+			flex:     &View{Width: 100, Height: 100, Direction: Row},
+			children: []*View{{Width: 100, Shrink: 1, MinWidth: 80}, {Width: 100, Shrink: 1}},
+			want:     []image.Rectangle{image.Rect(0, 0, 80, 100), image.Rect(80, 0, 100, 100)},
+		},
+		{
+			name:     "max-width in percent",
+			flex:     &View{Width: 400, Height: 100, Direction: Row},
+			children: []*View{{Grow: 1, MaxWidthInPct: 50}},
+			want:     []image.Rectangle{image.Rect(0, 0, 200, 100)},
+		},
+		{
+			name:     "min-height on fixed size",
+			flex:     &View{Width: 100, Height: 400, Direction: Column, AlignItems: AlignItemStart},
+			children: []*View{{Width: 10, Height: 10, MinHeight: 48}},
+			want:     []image.Rectangle{image.Rect(0, 0, 10, 48)},
+		},
+		{
+			name:     "stretch with max-height",
+			flex:     &View{Width: 100, Height: 100, Direction: Row, AlignItems: AlignItemStretch},
+			children: []*View{{Width: 10, MaxHeight: 40}},
+			want:     []image.Rectangle{image.Rect(0, 0, 10, 40)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mocks := make([]mockHandler, len(tt.children))
+			for i, c := range tt.children {
+				c.Handler = &mocks[i]
+				tt.flex.AddChild(c)
+			}
+
+			tt.flex.Update()
+			tt.flex.Draw(nil)
+
+			for i, want := range tt.want {
+				assert.Equal(t, want, mocks[i].Frame)
+			}
+		})
+	}
+}
+
+func TestIntrinsicMainSizeKeepsItemSizes(t *testing.T) {
+	// The intrinsic main size of the container is computed after the items
+	// are flexed, and it must not change the flexed, clamped sizes of the
+	// items.
+	flex := &View{Width: 300, Height: 100, Direction: Row}
+	mocks := [2]mockHandler{}
+	flex.AddChild(
+		&View{Grow: 1, MaxWidth: 50, Handler: &mocks[0]},
+		&View{Grow: 2, Handler: &mocks[1]},
+	)
+
+	flex.Update()
+	flex.Draw(nil)
+
+	require.Equal(t, image.Rect(0, 0, 50, 100), mocks[0].Frame)
+	require.Equal(t, image.Rect(50, 0, 300, 100), mocks[1].Frame)
+}
+
+func TestFlexBasis(t *testing.T) {
+	var tests = []struct {
+		name     string
+		children []*View
+		want     []image.Rectangle
+	}{
+		{
+			name: "zero basis makes equal columns",
+			children: []*View{
+				{Width: 10, Grow: 1, Shrink: 1, Basis: Int(0)},
+				{Width: 150, Grow: 1, Shrink: 1, Basis: Int(0)},
+				{Grow: 1, Shrink: 1, Basis: Int(0)},
+			},
+			want: []image.Rectangle{
+				image.Rect(0, 0, 100, 100),
+				image.Rect(100, 0, 200, 100),
+				image.Rect(200, 0, 300, 100),
+			},
+		},
+		{
+			name: "basis overrides width",
+			children: []*View{
+				{Width: 10, Basis: Int(120)},
+				{Width: 10},
+			},
+			want: []image.Rectangle{
+				image.Rect(0, 0, 120, 100),
+				image.Rect(120, 0, 130, 100),
+			},
+		},
+		{
+			name: "basis in percent",
+			children: []*View{
+				{BasisInPct: 25},
+				{Grow: 1},
+			},
+			want: []image.Rectangle{
+				image.Rect(0, 0, 75, 100),
+				image.Rect(75, 0, 300, 100),
+			},
+		},
+		{
+			name: "shrink from basis",
+			children: []*View{
+				{Shrink: 1, Basis: Int(200)},
+				{Shrink: 1, Basis: Int(200)},
+			},
+			want: []image.Rectangle{
+				image.Rect(0, 0, 150, 100),
+				image.Rect(150, 0, 300, 100),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			flex := &View{Width: 300, Height: 100, Direction: Row}
+			mocks := make([]mockHandler, len(tt.children))
+			for i, c := range tt.children {
+				c.Handler = &mocks[i]
+				flex.AddChild(c)
+			}
+
+			flex.Update()
+			flex.Draw(nil)
+
+			for i, want := range tt.want {
+				assert.Equal(t, want, mocks[i].Frame)
+			}
+		})
+	}
+}
+
+func TestAspectRatio(t *testing.T) {
+	var tests = []struct {
+		name     string
+		flex     *View
+		children []*View
+		want     []image.Rectangle
+	}{
+		{
+			name:     "width from height",
+			flex:     &View{Width: 600, Height: 200, Direction: Row, AlignItems: AlignItemStart},
+			children: []*View{{Height: 50, AspectRatio: 2}},
+			want:     []image.Rectangle{image.Rect(0, 0, 100, 50)},
+		},
+		{
+			name:     "width from height in percent",
+			flex:     &View{Width: 600, Height: 200, Direction: Row, AlignItems: AlignItemStart},
+			children: []*View{{HeightInPct: 50, AspectRatio: 2}},
+			want:     []image.Rectangle{image.Rect(0, 0, 200, 100)},
+		},
+		{
+			name:     "height from grown width",
+			flex:     &View{Width: 600, Height: 400, Direction: Row, AlignItems: AlignItemStart},
+			children: []*View{{Grow: 1, AspectRatio: 2}, {Width: 200, Height: 10}},
+			want:     []image.Rectangle{image.Rect(0, 0, 400, 200), image.Rect(400, 0, 600, 10)},
+		},
+		{
+			name:     "width from stretched height",
+			flex:     &View{Width: 600, Height: 100, Direction: Row},
+			children: []*View{{AspectRatio: 1.5}},
+			want:     []image.Rectangle{image.Rect(0, 0, 150, 100)},
+		},
+		{
+			name:     "height from shrunk width",
+			flex:     &View{Width: 300, Height: 200, Direction: Row},
+			children: []*View{{Shrink: 1, AspectRatio: 2}, {Width: 100}},
+			want:     []image.Rectangle{image.Rect(0, 0, 200, 100), image.Rect(200, 0, 300, 200)},
+		},
+		{
+			name:     "height from stretched width in column",
+			flex:     &View{Width: 300, Height: 600, Direction: Column},
+			children: []*View{{AspectRatio: 1.5}},
+			want:     []image.Rectangle{image.Rect(0, 0, 300, 200)},
+		},
+		{
+			name:     "ignored when both sizes are set",
+			flex:     &View{Width: 600, Height: 200, Direction: Row},
+			children: []*View{{Width: 10, Height: 20, AspectRatio: 2}},
+			want:     []image.Rectangle{image.Rect(0, 0, 10, 20)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mocks := make([]mockHandler, len(tt.children))
+			for i, c := range tt.children {
+				c.Handler = &mocks[i]
+				tt.flex.AddChild(c)
+			}
+
+			tt.flex.Update()
+			tt.flex.Draw(nil)
+
+			for i, want := range tt.want {
+				assert.Equal(t, want, mocks[i].Frame)
+			}
+		})
+	}
+}
+
+func TestAlignSelf(t *testing.T) {
+	flex := &View{
+		Width:      300,
+		Height:     100,
+		Direction:  Row,
+		AlignItems: AlignItemStretch,
+	}
+
+	mocks := [4]mockHandler{}
+	flex.AddChild(
+		&View{Width: 50, Handler: &mocks[0]},
+		&View{Width: 50, Height: 20, AlignSelf: AlignSelfCenter, Handler: &mocks[1]},
+		&View{Width: 50, Height: 20, AlignSelf: AlignSelfEnd, Handler: &mocks[2]},
+		&View{Width: 50, Height: 20, AlignSelf: AlignSelfStart, Handler: &mocks[3]},
+	)
+
+	flex.Update()
+	flex.Draw(nil)
+
+	assert.Equal(t, image.Rect(0, 0, 50, 100), mocks[0].Frame)
+	assert.Equal(t, image.Rect(50, 40, 100, 60), mocks[1].Frame)
+	assert.Equal(t, image.Rect(100, 80, 150, 100), mocks[2].Frame)
+	assert.Equal(t, image.Rect(150, 0, 200, 20), mocks[3].Frame)
+}
+
+func TestAlignSelfStretch(t *testing.T) {
+	flex := &View{
+		Width:      300,
+		Height:     100,
+		Direction:  Row,
+		AlignItems: AlignItemCenter,
+	}
+
+	mock := mockHandler{}
+	flex.AddChild(&View{Width: 50, AlignSelf: AlignSelfStretch, Handler: &mock})
+
+	flex.Update()
+	flex.Draw(nil)
+
+	assert.Equal(t, image.Rect(0, 0, 50, 100), mock.Frame)
+}
+
+func TestBaseline(t *testing.T) {
+	var tests = []struct {
+		name      string
+		flex      *View
+		children  []*View
+		baselines []int // -1 means the handler has no baseline
+		want      []image.Rectangle
+	}{
+		{
+			name:      "single line",
+			flex:      &View{Width: 300, Height: 100, Direction: Row, AlignItems: AlignItemBaseline},
+			children:  []*View{{Width: 50, Height: 40}, {Width: 50, Height: 20}},
+			baselines: []int{30, 10},
+			want:      []image.Rectangle{image.Rect(0, 0, 50, 40), image.Rect(50, 20, 100, 40)},
+		},
+		{
+			name:      "margin",
+			flex:      &View{Width: 300, Height: 100, Direction: Row, AlignItems: AlignItemBaseline},
+			children:  []*View{{Width: 50, Height: 40}, {Width: 50, Height: 20, MarginTop: 5}},
+			baselines: []int{30, 10},
+			want:      []image.Rectangle{image.Rect(0, 0, 50, 40), image.Rect(50, 20, 100, 40)},
+		},
+		{
+			name:      "synthesized from bottom edge",
+			flex:      &View{Width: 300, Height: 100, Direction: Row, AlignItems: AlignItemBaseline},
+			children:  []*View{{Width: 50, Height: 40}, {Width: 50, Height: 10}},
+			baselines: []int{30, -1},
+			want:      []image.Rectangle{image.Rect(0, 0, 50, 40), image.Rect(50, 20, 100, 30)},
+		},
+		{
+			name:      "text",
+			flex:      &View{Width: 300, Height: 100, Direction: Row, AlignItems: AlignItemBaseline},
+			children:  []*View{{Width: 50, Height: 40}, {Width: 50, Height: 20, Text: "HP"}},
+			baselines: []int{30, -1},
+			want:      []image.Rectangle{image.Rect(0, 0, 50, 40), image.Rect(50, 14, 100, 34)},
+		},
+		{
+			name: "multi line",
+			flex: &View{Width: 100, Height: 300, Direction: Row, Wrap: Wrap, AlignItems: AlignItemBaseline},
+			children: []*View{
+				{Width: 50, Height: 40},
+				{Width: 50, Height: 20},
+				{Width: 100, Height: 10},
+			},
+			baselines: []int{10, 18, -1},
+			want: []image.Rectangle{
+				image.Rect(0, 8, 50, 48),
+				image.Rect(50, 0, 100, 20),
+				image.Rect(0, 48, 100, 58),
+			},
+		},
+		{
+			name: "align-self",
+			flex: &View{Width: 300, Height: 100, Direction: Row, AlignItems: AlignItemCenter},
+			children: []*View{
+				{Width: 50, Height: 40, AlignSelf: AlignSelfBaseline},
+				{Width: 50, Height: 20, AlignSelf: AlignSelfBaseline},
+				{Width: 50, Height: 20},
+			},
+			baselines: []int{30, 10, 10},
+			want: []image.Rectangle{
+				image.Rect(0, 0, 50, 40),
+				image.Rect(50, 20, 100, 40),
+				image.Rect(100, 40, 150, 60),
+			},
+		},
+		{
+			name:      "wrap-reverse",
+			flex:      &View{Width: 300, Height: 100, Direction: Row, Wrap: WrapReverse, AlignItems: AlignItemBaseline},
+			children:  []*View{{Width: 50, Height: 40}, {Width: 50, Height: 20}},
+			baselines: []int{30, 10},
+			want:      []image.Rectangle{image.Rect(0, 60, 50, 100), image.Rect(50, 80, 100, 100)},
+		},
+		{
+			name:      "column falls back to flex-start",
+			flex:      &View{Width: 100, Height: 100, Direction: Column, AlignItems: AlignItemBaseline},
+			children:  []*View{{Width: 10, Height: 10}},
+			baselines: []int{5},
+			want:      []image.Rectangle{image.Rect(0, 0, 10, 10)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mocks := make([]mockBaseliner, len(tt.children))
+			for i, c := range tt.children {
+				if tt.baselines[i] < 0 {
+					c.Handler = &mocks[i].mockHandler
+				} else {
+					mocks[i].baseline = tt.baselines[i]
+					c.Handler = &mocks[i]
+				}
+				tt.flex.AddChild(c)
+			}
+
+			tt.flex.Update()
+			tt.flex.Draw(nil)
+
+			for i, want := range tt.want {
+				assert.Equal(t, want, mocks[i].Frame)
+			}
+		})
+	}
+}
+
+func TestBaselineNested(t *testing.T) {
+	flex := &View{
+		Width:      300,
+		Height:     100,
+		Direction:  Row,
+		AlignItems: AlignItemBaseline,
+	}
+
+	big := mockBaseliner{baseline: 30}
+	small := mockBaseliner{baseline: 8}
+	flex.AddChild(
+		&View{Width: 50, Height: 40, Handler: &big},
+		(&View{
+			Width:      50,
+			Height:     30,
+			PaddingTop: 4,
+			Direction:  Column,
+			AlignItems: AlignItemStart,
+		}).AddChild(&View{Width: 50, Height: 10, Handler: &small}),
+	)
+
+	flex.Update()
+	flex.Draw(nil)
+
+	// The baseline of the nested view is the one of its first child.
+	assert.Equal(t, image.Rect(0, 0, 50, 40), big.Frame)
+	assert.Equal(t, image.Rect(50, 22, 100, 32), small.Frame)
+}
+
+type mockBaseliner struct {
+	mockHandler
+	baseline int
+}
+
+func (h *mockBaseliner) Baseline(width, height int) int {
+	return h.baseline
+}
+
+func TestReverse(t *testing.T) {
+	var tests = []struct {
+		name     string
+		flex     *View
+		children []*View
+		want     []image.Rectangle
+	}{
+		{
+			name:     "row-reverse",
+			flex:     &View{Width: 300, Height: 100, Direction: RowReverse, AlignItems: AlignItemStart},
+			children: []*View{{Width: 50, Height: 50}, {Width: 50, Height: 50}},
+			want:     []image.Rectangle{image.Rect(250, 0, 300, 50), image.Rect(200, 0, 250, 50)},
+		},
+		{
+			name:     "row-reverse, justify end",
+			flex:     &View{Width: 300, Height: 100, Direction: RowReverse, Justify: JustifyEnd, AlignItems: AlignItemEnd},
+			children: []*View{{Width: 50, Height: 50}, {Width: 50, Height: 50}},
+			want:     []image.Rectangle{image.Rect(50, 50, 100, 100), image.Rect(0, 50, 50, 100)},
+		},
+		{
+			name:     "column-reverse with margin",
+			flex:     &View{Width: 100, Height: 300, Direction: ColumnReverse, AlignItems: AlignItemStart},
+			children: []*View{{Width: 50, Height: 50, MarginBottom: 10}, {Width: 50, Height: 50}},
+			want:     []image.Rectangle{image.Rect(0, 240, 50, 290), image.Rect(0, 190, 50, 240)},
+		},
+		{
+			name: "wrap-reverse",
+			flex: &View{Width: 200, Height: 300, Direction: Row, Wrap: WrapReverse, AlignItems: AlignItemStart},
+			children: []*View{
+				{Width: 100, Height: 100},
+				{Width: 100, Height: 50},
+				{Width: 100, Height: 100},
+			},
+			want: []image.Rectangle{
+				image.Rect(0, 200, 100, 300),
+				image.Rect(100, 250, 200, 300),
+				image.Rect(0, 100, 100, 200),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mocks := make([]mockHandler, len(tt.children))
+			for i, c := range tt.children {
+				c.Handler = &mocks[i]
+				tt.flex.AddChild(c)
+			}
+
+			tt.flex.Update()
+			tt.flex.Draw(nil)
+
+			for i, want := range tt.want {
+				assert.Equal(t, want, mocks[i].Frame)
+			}
+		})
+	}
+}
+
+func TestOrder(t *testing.T) {
+	flex := &View{
+		Width:      300,
+		Height:     100,
+		Direction:  Row,
+		AlignItems: AlignItemStart,
+	}
+
+	mocks := [3]mockHandler{}
+	flex.AddChild(
+		&View{Width: 10, Height: 10, Order: 1, Handler: &mocks[0]},
+		&View{Width: 20, Height: 10, Handler: &mocks[1]},
+		&View{Width: 30, Height: 10, Order: -1, Handler: &mocks[2]},
+	)
+
+	flex.Update()
+	flex.Draw(nil)
+
+	assert.Equal(t, image.Rect(30, 0, 50, 10), mocks[1].Frame)
+	assert.Equal(t, image.Rect(0, 0, 30, 10), mocks[2].Frame)
+	assert.Equal(t, image.Rect(50, 0, 60, 10), mocks[0].Frame)
+
+	flex.children[2].item.SetOrder(2)
+	flex.Update()
+	flex.Draw(nil)
+
+	assert.Equal(t, image.Rect(0, 0, 20, 10), mocks[1].Frame)
+	assert.Equal(t, image.Rect(20, 0, 30, 10), mocks[0].Frame)
+	assert.Equal(t, image.Rect(30, 0, 60, 10), mocks[2].Frame)
+}
+
+func TestAutoMargin(t *testing.T) {
+	var tests = []struct {
+		name     string
+		flex     *View
+		children []*View
+		want     []image.Rectangle
+	}{
+		{
+			name: "push to the end",
+			flex: &View{Width: 300, Height: 40, Direction: Row, Justify: JustifyCenter},
+			children: []*View{
+				{Width: 100},
+				{Width: 30, MarginLeftAuto: true},
+			},
+			want: []image.Rectangle{
+				image.Rect(0, 0, 100, 40),
+				image.Rect(270, 0, 300, 40),
+			},
+		},
+		{
+			name: "center in main axis",
+			flex: &View{Width: 300, Height: 40, Direction: Row},
+			children: []*View{
+				{Width: 100, MarginLeftAuto: true, MarginRightAuto: true},
+			},
+			want: []image.Rectangle{
+				image.Rect(100, 0, 200, 40),
+			},
+		},
+		{
+			name: "ignored on negative free space",
+			flex: &View{Width: 100, Height: 40, Direction: Row},
+			children: []*View{
+				{Width: 80},
+				{Width: 80, MarginLeftAuto: true},
+			},
+			want: []image.Rectangle{
+				image.Rect(0, 0, 80, 40),
+				image.Rect(80, 0, 160, 40),
+			},
+		},
+		{
+			name: "cross axis",
+			flex: &View{Width: 100, Height: 100, Direction: Row, AlignItems: AlignItemStretch},
+			children: []*View{
+				{Width: 20, Height: 20, MarginTopAuto: true},
+				{Width: 20, Height: 20, MarginTopAuto: true, MarginBottomAuto: true},
+			},
+			want: []image.Rectangle{
+				image.Rect(0, 80, 20, 100),
+				image.Rect(20, 40, 40, 60),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mocks := make([]mockHandler, len(tt.children))
+			for i, c := range tt.children {
+				c.Handler = &mocks[i]
+				tt.flex.AddChild(c)
+			}
+
+			tt.flex.Update()
+			tt.flex.Draw(nil)
+
+			for i, want := range tt.want {
+				assert.Equal(t, want, mocks[i].Frame)
+			}
+		})
+	}
+}
+
+func TestSpaceEvenly(t *testing.T) {
+	t.Run("justify-content", func(t *testing.T) {
+		flex := &View{
+			Width:      400,
+			Height:     100,
+			Direction:  Row,
+			Justify:    JustifySpaceEvenly,
+			AlignItems: AlignItemStart,
+		}
+
+		mocks := [3]mockHandler{}
+		for i := range mocks {
+			flex.AddChild(&View{Width: 100, Height: 10, Handler: &mocks[i]})
+		}
+
+		flex.Update()
+		flex.Draw(nil)
+
+		assert.Equal(t, image.Rect(25, 0, 125, 10), mocks[0].Frame)
+		assert.Equal(t, image.Rect(150, 0, 250, 10), mocks[1].Frame)
+		assert.Equal(t, image.Rect(275, 0, 375, 10), mocks[2].Frame)
+	})
+
+	t.Run("justify-content without free space", func(t *testing.T) {
+		flex := &View{
+			Width:      100,
+			Height:     100,
+			Direction:  Row,
+			Justify:    JustifySpaceEvenly,
+			AlignItems: AlignItemStart,
+		}
+
+		mocks := [2]mockHandler{}
+		for i := range mocks {
+			flex.AddChild(&View{Width: 60, Height: 10, Handler: &mocks[i]})
+		}
+
+		flex.Update()
+		flex.Draw(nil)
+
+		// Falls back to center.
+		assert.Equal(t, image.Rect(-10, 0, 50, 10), mocks[0].Frame)
+		assert.Equal(t, image.Rect(50, 0, 110, 10), mocks[1].Frame)
+	})
+
+	t.Run("align-content", func(t *testing.T) {
+		flex := &View{
+			Width:        100,
+			Height:       300,
+			Direction:    Row,
+			Wrap:         Wrap,
+			AlignItems:   AlignItemStart,
+			AlignContent: AlignContentSpaceEvenly,
+		}
+
+		mocks := [2]mockHandler{}
+		for i := range mocks {
+			flex.AddChild(&View{Width: 100, Height: 50, Handler: &mocks[i]})
+		}
+
+		flex.Update()
+		flex.Draw(nil)
+
+		assert.Equal(t, image.Rect(0, 67, 100, 117), mocks[0].Frame)
+		assert.Equal(t, image.Rect(0, 183, 100, 233), mocks[1].Frame)
+	})
+}
+
+func TestSpaceBetweenSingleItem(t *testing.T) {
+	got := flexItemBounds(&View{
+		Width:      100,
+		Height:     100,
+		Justify:    JustifySpaceBetween,
+		AlignItems: AlignItemStart,
+	}, &View{Width: 10, Height: 10})
+	assert.Equal(t, image.Rect(0, 0, 10, 10), got)
+}
+
 func flexItemBounds(parent *View, child *View) image.Rectangle {
 	mock := &mockHandler{}
 	child.Handler = mock
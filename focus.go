@@ -0,0 +1,198 @@
+package furex
+
+import (
+	"sort"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+)
+
+// focusState is the keyboard focus of a tree, held by its root.
+type focusState struct {
+	focused *View
+	// pressed is the button pressed by Enter or Space.
+	pressed *View
+}
+
+// isFocusable reports whether the view can receive the focus, which is
+// when its handler implements Focusable or is a button.
+func (v *View) isFocusable() bool {
+	if _, ok := v.Handler.(Focusable); ok {
+		return true
+	}
+	_, ok := v.button()
+	return ok
+}
+
+func (v *View) root() *View {
+	r := v
+	for r.hasParent {
+		r = r.parent
+	}
+	return r
+}
+
+// Focus gives the keyboard focus to the view, taking it from the focused
+// view of the tree. Views that are not focusable are ignored.
+func (v *View) Focus() {
+	if !v.isFocusable() {
+		return
+	}
+	root := v.root()
+	old := root.Focused()
+	if old == v {
+		return
+	}
+	root.focus.focused = v
+	if old != nil {
+		old.handleBlur()
+	}
+	if f, ok := v.Handler.(Focusable); ok {
+		f.HandleFocus()
+	}
+}
+
+// Blur removes the keyboard focus from the view if it has it.
+func (v *View) Blur() {
+	root := v.root()
+	if root.Focused() != v {
+		return
+	}
+	root.focus.focused = nil
+	v.handleBlur()
+}
+
+// IsFocused reports whether the view has the keyboard focus.
+func (v *View) IsFocused() bool {
+	return v.root().Focused() == v
+}
+
+// Focused returns the view that has the keyboard focus in the tree
+// of the view, or nil.
+func (v *View) Focused() *View {
+	root := v.root()
+	f := root.focus.focused
+	if f != nil && f.root() != root {
+		// The view was removed from the tree.
+		root.focus.focused = nil
+		return nil
+	}
+	return f
+}
+
+func (v *View) handleBlur() {
+	v.cancelKeyPress()
+	if f, ok := v.Handler.(Focusable); ok {
+		f.HandleBlur()
+	}
+}
+
+// focusOrder returns the views of the tree that are reached by Tab:
+// the views with a positive tab index in ascending order, and then the
+// ones whose tab index is 0 in tree order. Hidden views are skipped.
+func (v *View) focusOrder() []*View {
+	var views []*View
+	var walk func(v *View)
+	walk = func(v *View) {
+		if v.Hidden || v.Display == DisplayNone {
+			return
+		}
+		if v.TabIndex >= 0 && v.isFocusable() {
+			views = append(views, v)
+		}
+		for _, c := range v.children {
+			walk(c.item)
+		}
+	}
+	walk(v)
+	sort.SliceStable(views, func(i, j int) bool {
+		a, b := views[i].TabIndex, views[j].TabIndex
+		return a > 0 && (b == 0 || a < b)
+	})
+	return views
+}
+
+// moveFocus moves the focus to the next view in the focus order,
+// or the previous one, wrapping around.
+func (v *View) moveFocus(forward bool) {
+	views := v.focusOrder()
+	if len(views) == 0 {
+		return
+	}
+	i := -1
+	focused := v.Focused()
+	for j, view := range views {
+		if view == focused {
+			i = j
+			break
+		}
+	}
+	switch {
+	case forward:
+		i = (i + 1) % len(views)
+	case i < 0:
+		i = len(views) - 1
+	default:
+		i = (i - 1 + len(views)) % len(views)
+	}
+	views[i].Focus()
+}
+
+// pressFocused presses the focused button, as with a mouse.
+func (v *View) pressFocused() {
+	f := v.Focused()
+	if f == nil || v.focus.pressed != nil {
+		return
+	}
+	if b, ok := f.button(); ok {
+		v.focus.pressed = f
+		c := f.frame.Min.Add(f.frame.Size().Div(2))
+		b.HandlePress(c.X, c.Y, -1)
+	}
+}
+
+// releaseFocused releases the button pressed by pressFocused.
+func (v *View) releaseFocused() {
+	p := v.focus.pressed
+	if p == nil {
+		return
+	}
+	v.focus.pressed = nil
+	if b, ok := p.Handler.(ButtonHandler); ok {
+		c := p.frame.Min.Add(p.frame.Size().Div(2))
+		b.HandleRelease(c.X, c.Y, false)
+	}
+}
+
+// cancelKeyPress cancels the press of the view by Enter or Space,
+// because it lost the focus.
+func (v *View) cancelKeyPress() {
+	root := v.root()
+	if root.focus.pressed != v {
+		return
+	}
+	root.focus.pressed = nil
+	if b, ok := v.Handler.(ButtonHandler); ok {
+		c := v.frame.Min.Add(v.frame.Size().Div(2))
+		b.HandleRelease(c.X, c.Y, true)
+	}
+}
+
+var activationKeys = []ebiten.Key{ebiten.KeyEnter, ebiten.KeyNumpadEnter, ebiten.KeySpace}
+
+// handleFocusKeys moves the focus with Tab and Shift+Tab, and presses
+// the focused button with Enter and Space. The keys handled by a
+// KeyHandler are skipped.
+func (v *View) handleFocusKeys(handled map[ebiten.Key]bool) {
+	if inpututil.IsKeyJustPressed(ebiten.KeyTab) && !handled[ebiten.KeyTab] {
+		v.moveFocus(!ebiten.IsKeyPressed(ebiten.KeyShift))
+	}
+	for _, k := range activationKeys {
+		if inpututil.IsKeyJustPressed(k) && !handled[k] {
+			v.pressFocused()
+		}
+		if inpututil.IsKeyJustReleased(k) {
+			v.releaseFocused()
+		}
+	}
+}
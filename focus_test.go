@@ -0,0 +1,149 @@
+package furex
+
+import (
+	"image"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+
+	"github.com/stretchr/testify/require"
+)
+
+type focusHandler struct {
+	focused, blurred int
+}
+
+func (h *focusHandler) HandleFocus() { h.focused++ }
+func (h *focusHandler) HandleBlur()  { h.blurred++ }
+
+func TestFocus(t *testing.T) {
+	a, b := &focusHandler{}, &focusHandler{}
+	va, vb := &View{Handler: a}, &View{Handler: b}
+	plain := &View{}
+	root := (&View{Width: 100, Height: 100}).AddChild(va, (&View{}).AddChild(vb), plain)
+
+	va.Focus()
+	require.True(t, va.IsFocused())
+	require.Equal(t, va, root.Focused())
+	require.Equal(t, 1, a.focused)
+
+	va.Focus()
+	require.Equal(t, 1, a.focused, "focusing again does nothing")
+
+	vb.Focus()
+	require.False(t, va.IsFocused())
+	require.True(t, vb.IsFocused())
+	require.Equal(t, 1, a.blurred)
+	require.Equal(t, 1, b.focused)
+
+	plain.Focus()
+	require.True(t, vb.IsFocused(), "views that aren't focusable are ignored")
+
+	va.Blur()
+	require.Equal(t, 0, b.blurred, "blurring a view without the focus does nothing")
+	vb.Blur()
+	require.Nil(t, root.Focused())
+	require.Equal(t, 1, b.blurred)
+
+	va.Focus()
+	root.RemoveChild(va)
+	require.Nil(t, root.Focused(), "removed views lose the focus")
+}
+
+func TestTabOrder(t *testing.T) {
+	views := map[string]*View{
+		"a":      {Handler: &focusHandler{}},
+		"b":      {Handler: &mockHandler{}},
+		"first":  {Handler: &focusHandler{}, TabIndex: 1},
+		"second": {Handler: &focusHandler{}, TabIndex: 2},
+		"skip":   {Handler: &focusHandler{}, TabIndex: -1},
+		"hidden": {Handler: &focusHandler{}, Hidden: true},
+		"c":      {Handler: &focusHandler{}},
+	}
+	root := (&View{Width: 100, Height: 100}).AddChild(
+		views["a"],
+		(&View{}).AddChild(views["b"], views["second"]),
+		views["skip"],
+		views["hidden"],
+		views["first"],
+		views["c"],
+	)
+	name := func(v *View) string {
+		for k, view := range views {
+			if view == v {
+				return k
+			}
+		}
+		return ""
+	}
+
+	var forward []string
+	for i := 0; i < 6; i++ {
+		root.moveFocus(true)
+		forward = append(forward, name(root.Focused()))
+	}
+	require.Equal(t, []string{"first", "second", "a", "b", "c", "first"}, forward)
+
+	views["skip"].Focus()
+	var backward []string
+	for i := 0; i < 3; i++ {
+		root.moveFocus(false)
+		backward = append(backward, name(root.Focused()))
+	}
+	require.Equal(t, []string{"c", "b", "a"}, backward)
+}
+
+// notButton is a ButtonHandler that opts out of being a button.
+type notButton struct {
+	mockHandler
+}
+
+func (h *notButton) IsButton() bool { return false }
+
+func TestTabSkipsNotButtons(t *testing.T) {
+	a, b := &View{Handler: &focusHandler{}}, &View{Handler: &mockHandler{}}
+	not := &View{Handler: &notButton{}}
+	drawOnly := &View{Handler: NewHandler(HandlerOpts{Draw: func(*ebiten.Image, image.Rectangle, *View) {}})}
+	button := &View{Handler: NewHandler(HandlerOpts{HandlePress: func(int, int, ebiten.TouchID) {}})}
+	root := (&View{Width: 100, Height: 100}).AddChild(a, not, drawOnly, b, button)
+
+	var focused []*View
+	for i := 0; i < 4; i++ {
+		root.moveFocus(true)
+		focused = append(focused, root.Focused())
+	}
+	require.Equal(t, []*View{a, b, button, a}, focused)
+
+	not.Focus()
+	drawOnly.Focus()
+	require.Equal(t, a, root.Focused(), "views that aren't buttons can't be focused")
+}
+
+func TestFocusActivation(t *testing.T) {
+	mock := &mockHandler{}
+	button := &View{Width: 20, Height: 10, Handler: mock}
+	root := (&View{Width: 100, Height: 100}).AddChild(button)
+	root.Update()
+
+	root.pressFocused()
+	require.False(t, mock.IsPressed, "nothing is focused")
+
+	button.Focus()
+	root.pressFocused()
+	require.True(t, mock.IsPressed)
+	require.False(t, mock.IsReleased)
+	root.releaseFocused()
+	require.True(t, mock.IsReleased)
+	require.False(t, mock.IsCancel)
+
+	// Losing the focus cancels the press.
+	mock.Init()
+	root.pressFocused()
+	require.True(t, mock.IsPressed)
+	button.Blur()
+	require.True(t, mock.IsReleased)
+	require.True(t, mock.IsCancel)
+	mock.Init()
+	root.releaseFocused()
+	require.False(t, mock.IsReleased)
+}
@@ -0,0 +1,390 @@
+package furex
+
+import (
+	"fmt"
+	"image"
+	"math"
+)
+
+// GridTrackUnit is the unit of the size of a grid track.
+type GridTrackUnit uint8
+
+const (
+	// GridTrackAuto sizes the track to the largest item in it. The auto
+	// tracks are stretched to the free space when there is no fr track.
+	GridTrackAuto GridTrackUnit = iota
+	GridTrackPx
+	GridTrackPct
+	// GridTrackFr sizes the track to a fraction of the free space.
+	GridTrackFr
+)
+
+// GridTrack is the size of a column or a row of a grid,
+// an item of 'grid-template-columns' or 'grid-template-rows'.
+type GridTrack struct {
+	Size float64
+	Unit GridTrackUnit
+}
+
+func (t GridTrack) String() string {
+	switch t.Unit {
+	case GridTrackAuto:
+		return "auto"
+	case GridTrackPx:
+		return fmt.Sprintf("%gpx", t.Size)
+	case GridTrackPct:
+		return fmt.Sprintf("%g%%", t.Size)
+	case GridTrackFr:
+		return fmt.Sprintf("%gfr", t.Size)
+	}
+	return fmt.Sprintf("unknown grid track unit: %d", t.Unit)
+}
+
+// GridPlacement places a grid item on an axis: 'grid-column' or 'grid-row'.
+// Lines are numbered from 1, and negative lines count back from the end of
+// the explicit grid. A zero line is auto, and the item is placed
+// automatically in the first free area.
+type GridPlacement struct {
+	Start int
+	End   int
+	// Span is the number of tracks the item spans when its end is auto.
+	// Zero means 1.
+	Span int
+}
+
+// resolve returns the index of the first track of the placement, or -1 if
+// it is auto, and the number of tracks it spans. The explicit grid has
+// n tracks.
+func (p GridPlacement) resolve(n int) (start, span int) {
+	line := func(l int) int {
+		if l > 0 {
+			return l - 1
+		}
+		return nonNegative(n + 1 + l)
+	}
+	start, span = -1, p.Span
+	if span < 1 {
+		span = 1
+	}
+	if p.Start != 0 {
+		start = line(p.Start)
+	}
+	if p.End != 0 {
+		end := line(p.End)
+		switch {
+		case start < 0:
+			start = nonNegative(end - span)
+		case end > start:
+			span = end - start
+		case end < start:
+			start, span = end, start-end
+		}
+	}
+	return start, span
+}
+
+type gridItem struct {
+	node      *child
+	col, row  int
+	colSpan   int
+	rowSpan   int
+	width     float64 // the width of the item, including its margins
+	height    float64 // the height of the item, including its margins
+	area      image.Rectangle
+	alignment AlignItem
+}
+
+// layoutGrid is the sibling of layout for views whose display is grid.
+// It implements a subset of the CSS grid layout: the explicit tracks sized
+// in px, %, fr or auto, implicit auto tracks, the row-major auto-placement,
+// and the gaps between tracks. Items are stretched to their area unless
+// they have a fixed size, and aligned vertically by align-items and
+// align-self.
+func (f *flexEmbed) layoutGrid(width, height int, container *containerEmbed) {
+	width = nonNegative(width - f.PaddingLeft - f.PaddingRight)
+	height = nonNegative(height - f.PaddingTop - f.PaddingBottom)
+
+	var items []*gridItem
+	for _, c := range container.orderedChildren() {
+		if c.item.Display == DisplayNone {
+			continue
+		}
+		if c.item.Position == PositionAbsolute {
+			f.layoutAbsolute(c, container)
+			continue
+		}
+		c.absolute = false
+		v := c.item
+		v.measure(width, height)
+		items = append(items, &gridItem{
+			node:   c,
+			width:  float64(v.width() + v.MarginLeft + v.MarginRight),
+			height: float64(v.height() + v.MarginTop + v.MarginBottom),
+		})
+	}
+
+	cols, rows := f.placeGridItems(items)
+	colSizes := sizeGridTracks(f.GridTemplateColumns, cols, width, f.ColumnGap, items,
+		func(it *gridItem) (int, int, float64) { return it.col, it.colSpan, it.width })
+	rowSizes := sizeGridTracks(f.GridTemplateRows, rows, height, f.RowGap, items,
+		func(it *gridItem) (int, int, float64) { return it.row, it.rowSpan, it.height })
+	colOffsets := gridTrackOffsets(colSizes, f.ColumnGap)
+	rowOffsets := gridTrackOffsets(rowSizes, f.RowGap)
+
+	// The intrinsic size of the grid is the size of its tracks sized to
+	// their content, without the free space of the frame.
+	intrinsicCols := sizeGridTracks(f.GridTemplateColumns, cols, 0, f.ColumnGap, items,
+		func(it *gridItem) (int, int, float64) { return it.col, it.colSpan, it.width })
+	intrinsicRows := sizeGridTracks(f.GridTemplateRows, rows, 0, f.RowGap, items,
+		func(it *gridItem) (int, int, float64) { return it.row, it.rowSpan, it.height })
+	f.calculatedWidth = round(gridTracksSize(intrinsicCols, f.ColumnGap)) + f.PaddingLeft + f.PaddingRight
+	f.calculatedHeight = round(gridTracksSize(intrinsicRows, f.RowGap)) + f.PaddingTop + f.PaddingBottom
+
+	padding := image.Pt(f.PaddingLeft, f.PaddingTop)
+	for _, it := range items {
+		x0, x1 := colOffsets[it.col], colOffsets[it.col+it.colSpan]-float64(f.ColumnGap)
+		y0, y1 := rowOffsets[it.row], rowOffsets[it.row+it.rowSpan]-float64(f.RowGap)
+		x, w := f.alignGridItem(it.node.item, x0, x1-x0, AlignItemStart, true)
+		y, h := f.alignGridItem(it.node.item, y0, y1-y0, f.alignItem(it.node.item), false)
+
+		c := it.node
+		c.bounds = image.Rect(round(x), round(y), round(x+w), round(y+h)).Add(padding)
+		if c.item.Position == PositionRelative {
+			c.bounds = c.bounds.Add(c.item.relativeOffset(width, height))
+		}
+		c.item.setFrame(c.bounds.Add(f.frame.Min))
+	}
+
+	f.scrollChildren(container)
+}
+
+// placeGridItems assigns the tracks of the items and returns the number of
+// columns and rows of the grid. The items with a definite row and column
+// are placed first, then the ones with a definite row, and then the others
+// in order, never going back before the last one placed.
+func (f *flexEmbed) placeGridItems(items []*gridItem) (cols, rows int) {
+	explicitCols, explicitRows := len(f.GridTemplateColumns), len(f.GridTemplateRows)
+	cols = explicitCols
+	for _, it := range items {
+		it.col, it.colSpan = it.node.item.GridColumn.resolve(explicitCols)
+		it.row, it.rowSpan = it.node.item.GridRow.resolve(explicitRows)
+		cols = maxInt(cols, maxInt(it.col, 0)+it.colSpan)
+	}
+	if cols == 0 {
+		cols = 1
+	}
+
+	occupied := map[image.Point]bool{}
+	fits := func(col, row int, it *gridItem) bool {
+		if col+it.colSpan > cols {
+			return false
+		}
+		for y := row; y < row+it.rowSpan; y++ {
+			for x := col; x < col+it.colSpan; x++ {
+				if occupied[image.Pt(x, y)] {
+					return false
+				}
+			}
+		}
+		return true
+	}
+	place := func(col, row int, it *gridItem) {
+		it.col, it.row = col, row
+		for y := row; y < row+it.rowSpan; y++ {
+			for x := col; x < col+it.colSpan; x++ {
+				occupied[image.Pt(x, y)] = true
+			}
+		}
+	}
+
+	for _, it := range items {
+		if it.col >= 0 && it.row >= 0 {
+			place(it.col, it.row, it)
+		}
+	}
+	for _, it := range items {
+		if it.col < 0 && it.row >= 0 {
+			col := 0
+			for col+it.colSpan < cols && !fits(col, it.row, it) {
+				col++
+			}
+			place(col, it.row, it)
+		}
+	}
+	var cursor image.Point
+	for _, it := range items {
+		if it.row >= 0 {
+			continue
+		}
+		if it.col >= 0 {
+			if it.col < cursor.X {
+				cursor.Y++
+			}
+			for !fits(it.col, cursor.Y, it) {
+				cursor.Y++
+			}
+			place(it.col, cursor.Y, it)
+		} else {
+			for {
+				if cursor.X+it.colSpan > cols {
+					cursor = image.Pt(0, cursor.Y+1)
+				}
+				if fits(cursor.X, cursor.Y, it) {
+					break
+				}
+				cursor.X++
+			}
+			place(cursor.X, cursor.Y, it)
+		}
+		cursor.X = it.col + it.colSpan
+	}
+
+	rows = explicitRows
+	for _, it := range items {
+		rows = maxInt(rows, it.row+it.rowSpan)
+	}
+	return cols, rows
+}
+
+// sizeGridTracks returns the sizes of the n tracks of an axis whose
+// available space is size. The tracks beyond the template are auto.
+// The track function returns the first track, the span and the size
+// of an item on the axis.
+func sizeGridTracks(template []GridTrack, n, size, gap int, items []*gridItem,
+	track func(*gridItem) (start, span int, size float64)) []float64 {
+	tracks := make([]GridTrack, n)
+	copy(tracks, template)
+	sizes := make([]float64, n)
+	for i, t := range tracks {
+		switch t.Unit {
+		case GridTrackPx:
+			sizes[i] = t.Size
+		case GridTrackPct:
+			sizes[i] = float64(size) * t.Size / 100
+		}
+	}
+
+	// Auto and fr tracks are at least as large as the items in them.
+	for _, it := range items {
+		start, span, s := track(it)
+		if span == 1 && (tracks[start].Unit == GridTrackAuto || tracks[start].Unit == GridTrackFr) {
+			sizes[start] = math.Max(sizes[start], s)
+		}
+	}
+	// The items spanning several tracks grow the auto tracks they span.
+	for _, it := range items {
+		start, span, s := track(it)
+		if span == 1 {
+			continue
+		}
+		var autos []int
+		excess := s - gapSize(float64(gap), span)
+		for i := start; i < start+span; i++ {
+			excess -= sizes[i]
+			if tracks[i].Unit == GridTrackAuto {
+				autos = append(autos, i)
+			}
+		}
+		if excess > 0 && len(autos) > 0 {
+			for _, i := range autos {
+				sizes[i] += excess / float64(len(autos))
+			}
+		}
+	}
+
+	free := float64(size) - gapSize(float64(gap), n)
+	var frs, autos []int
+	var frTotal float64
+	for i, t := range tracks {
+		switch t.Unit {
+		case GridTrackFr:
+			frs = append(frs, i)
+			frTotal += t.Size
+		case GridTrackAuto:
+			autos = append(autos, i)
+			free -= sizes[i]
+		default:
+			free -= sizes[i]
+		}
+	}
+	free = math.Max(0, free)
+	switch {
+	case len(frs) > 0:
+		fr := free / math.Max(frTotal, 1)
+		for _, i := range frs {
+			sizes[i] = math.Max(sizes[i], fr*tracks[i].Size)
+		}
+	case len(autos) > 0 && free > 0:
+		for _, i := range autos {
+			sizes[i] += free / float64(len(autos))
+		}
+	}
+	return sizes
+}
+
+// gridTrackOffsets returns the offsets of the start of each track, and of
+// the end of the last one. Each offset is followed by a gap.
+func gridTrackOffsets(sizes []float64, gap int) []float64 {
+	offsets := make([]float64, len(sizes)+1)
+	for i, s := range sizes {
+		offsets[i+1] = offsets[i] + s + float64(gap)
+	}
+	return offsets
+}
+
+// gridTracksSize returns the size of the tracks and the gaps between them.
+func gridTracksSize(sizes []float64, gap int) float64 {
+	size := gapSize(float64(gap), len(sizes))
+	for _, s := range sizes {
+		size += s
+	}
+	return size
+}
+
+// alignGridItem returns the offset and the size of the item v in its area
+// on an axis. Items without a fixed size are stretched unless they are
+// aligned otherwise.
+func (f *flexEmbed) alignGridItem(v *View, start, area float64, align AlignItem, horizontal bool) (float64, float64) {
+	var size float64
+	var margin [2]int
+	var fixed bool
+	if horizontal {
+		size, fixed = resolveLength(v.Width, v.WidthInPct, round(area)), v.isWidthFixed()
+		if !fixed {
+			size = float64(v.calculatedWidth)
+		}
+		margin = [2]int{v.MarginLeft, v.MarginRight}
+	} else {
+		size, fixed = resolveLength(v.Height, v.HeightInPct, round(area)), v.isHeightFixed()
+		if !fixed {
+			size = float64(v.calculatedHeight)
+		}
+		margin = [2]int{v.MarginTop, v.MarginBottom}
+	}
+	inner := area - float64(margin[0]+margin[1])
+	if !fixed && (align == AlignItemStretch || horizontal) {
+		size = inner
+	}
+	if horizontal {
+		size = v.clampWidth(size, round(area))
+	} else {
+		size = v.clampHeight(size, round(area))
+	}
+	size = math.Max(0, size)
+
+	offset := start + float64(margin[0])
+	switch align {
+	case AlignItemEnd:
+		offset += inner - size
+	case AlignItemCenter:
+		offset += (inner - size) / 2
+	}
+	return offset, size
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
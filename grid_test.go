@@ -0,0 +1,158 @@
+package furex
+
+import (
+	"image"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func px(v float64) GridTrack  { return GridTrack{Size: v, Unit: GridTrackPx} }
+func pct(v float64) GridTrack { return GridTrack{Size: v, Unit: GridTrackPct} }
+func fr(v float64) GridTrack  { return GridTrack{Size: v, Unit: GridTrackFr} }
+
+var auto = GridTrack{Unit: GridTrackAuto}
+
+func TestGrid(t *testing.T) {
+	var tests = []struct {
+		name     string
+		grid     *View
+		children []*View
+		want     []image.Rectangle
+	}{
+		{
+			name: "px and fr columns",
+			grid: &View{Width: 300, Height: 100, Display: DisplayGrid,
+				GridTemplateColumns: []GridTrack{px(100), fr(1), fr(1)}},
+			children: []*View{{}, {}, {}, {}},
+			want: []image.Rectangle{
+				image.Rect(0, 0, 100, 50),
+				image.Rect(100, 0, 200, 50),
+				image.Rect(200, 0, 300, 50),
+				image.Rect(0, 50, 100, 100),
+			},
+		},
+		{
+			name: "percent columns and fixed rows with gaps",
+			grid: &View{Width: 210, Height: 200, Display: DisplayGrid, RowGap: 10, ColumnGap: 10,
+				GridTemplateColumns: []GridTrack{pct(50), fr(1)},
+				GridTemplateRows:    []GridTrack{px(30), px(40)}},
+			children: []*View{{}, {}, {}},
+			want: []image.Rectangle{
+				image.Rect(0, 0, 105, 30),
+				image.Rect(115, 0, 210, 30),
+				image.Rect(0, 40, 105, 80),
+			},
+		},
+		{
+			name: "auto tracks fit their items and stretch",
+			grid: &View{Width: 300, Height: 100, Display: DisplayGrid, AlignItems: AlignItemStart,
+				GridTemplateColumns: []GridTrack{auto, px(100)},
+				GridTemplateRows:    []GridTrack{auto, px(20)}},
+			children: []*View{{Width: 50, Height: 30}, {Height: 10}, {}},
+			want: []image.Rectangle{
+				image.Rect(0, 0, 50, 30),
+				image.Rect(200, 0, 300, 10),
+				image.Rect(0, 80, 200, 80),
+			},
+		},
+		{
+			name: "spans and definite placement",
+			grid: &View{Width: 300, Height: 300, Display: DisplayGrid,
+				GridTemplateColumns: []GridTrack{fr(1), fr(1), fr(1)},
+				GridTemplateRows:    []GridTrack{fr(1), fr(1), fr(1)}},
+			children: []*View{
+				{GridColumn: GridPlacement{Span: 2}},
+				{GridRow: GridPlacement{Start: 2, End: -1}, GridColumn: GridPlacement{Start: 3}},
+				{},
+				{GridColumn: GridPlacement{Start: 1, End: 3}},
+			},
+			want: []image.Rectangle{
+				image.Rect(0, 0, 200, 100),
+				image.Rect(200, 100, 300, 300),
+				image.Rect(200, 0, 300, 100),
+				image.Rect(0, 100, 200, 200),
+			},
+		},
+		{
+			name: "implicit rows are auto",
+			grid: &View{Width: 200, Height: 100, Display: DisplayGrid, AlignItems: AlignItemCenter,
+				GridTemplateColumns: []GridTrack{fr(1), fr(1)},
+				GridTemplateRows:    []GridTrack{px(20)}},
+			children: []*View{{}, {}, {Height: 10}},
+			want: []image.Rectangle{
+				image.Rect(0, 10, 100, 10),
+				image.Rect(100, 10, 200, 10),
+				image.Rect(0, 55, 100, 65),
+			},
+		},
+		{
+			name: "margins, padding and alignment",
+			grid: &View{Width: 220, Height: 120, Display: DisplayGrid, PaddingLeft: 10, PaddingTop: 10,
+				PaddingRight: 10, PaddingBottom: 10,
+				GridTemplateColumns: []GridTrack{fr(1), fr(1)}},
+			children: []*View{
+				{MarginLeft: 5, MarginTop: 5, MarginRight: 5, MarginBottom: 5},
+				{Width: 20, Height: 20, AlignSelf: AlignSelfEnd},
+			},
+			want: []image.Rectangle{
+				image.Rect(15, 15, 105, 105),
+				image.Rect(110, 90, 130, 110),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mocks := make([]mockHandler, len(tt.children))
+			for i, c := range tt.children {
+				c.Handler = &mocks[i]
+				tt.grid.AddChild(c)
+			}
+
+			tt.grid.Update()
+			tt.grid.Draw(nil)
+
+			for i, want := range tt.want {
+				require.Equal(t, want, tt.children[i].frame, "child %d", i)
+			}
+		})
+	}
+}
+
+func TestGridPlacementResolve(t *testing.T) {
+	for _, tt := range []struct {
+		p           GridPlacement
+		start, span int
+	}{
+		{p: GridPlacement{}, start: -1, span: 1},
+		{p: GridPlacement{Span: 3}, start: -1, span: 3},
+		{p: GridPlacement{Start: 2}, start: 1, span: 1},
+		{p: GridPlacement{Start: 1, End: -1}, start: 0, span: 4},
+		{p: GridPlacement{Start: 3, End: 1}, start: 0, span: 2},
+		{p: GridPlacement{End: 4, Span: 2}, start: 1, span: 2},
+	} {
+		start, span := tt.p.resolve(4)
+		require.Equal(t, [2]int{tt.start, tt.span}, [2]int{start, span}, "%+v", tt.p)
+	}
+}
+
+func TestGridIntrinsicSize(t *testing.T) {
+	grid := &View{Display: DisplayGrid, ColumnGap: 4, RowGap: 2, PaddingLeft: 3,
+		GridTemplateColumns: []GridTrack{px(50), px(50)}}
+	for i := 0; i < 3; i++ {
+		grid.AddChild(&View{Height: 30})
+	}
+	wrapper := (&View{Direction: Column}).AddChild(grid)
+	root := (&View{Width: 300, Height: 300, AlignItems: AlignItemStart}).AddChild(wrapper)
+	root.Update()
+	root.Draw(nil)
+
+	require.Equal(t, image.Rect(0, 0, 107, 62), wrapper.frame)
+	require.Equal(t, image.Rect(0, 0, 107, 62), grid.frame)
+	require.Equal(t, image.Rect(3, 32, 53, 62), grid.children[2].item.frame)
+
+	// Growing an item grows the grid and its auto-sized ancestors.
+	grid.children[0].item.SetHeight(40)
+	root.Update()
+	require.Equal(t, image.Rect(0, 0, 107, 72), wrapper.frame)
+}
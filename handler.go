@@ -21,6 +21,61 @@ type Updater interface {
 	Update(v *View)
 }
 
+// MeasureMode tells how a Measurer should treat the available size.
+type MeasureMode uint8
+
+const (
+	// MeasureUndefined means there is no constraint on the size.
+	// The component should report the size of its whole content.
+	MeasureUndefined MeasureMode = iota
+	// MeasureAtMost means the component should fit in the available size
+	// if it can, e.g. by wrapping its text.
+	MeasureAtMost
+)
+
+// Measurer represents a component that has an intrinsic content size,
+// such as a text label or an image.
+// It is called for views without children whose width or height is not fixed.
+// The size is cached until the available size changes, so call Layout on the
+// view when the content changes.
+type Measurer interface {
+	// Measure returns the size of the content of the component, excluding padding.
+	// The parameters availableWidth and availableHeight are the space available
+	// for the content.
+	Measure(availableWidth, availableHeight int, mode MeasureMode) (width, height int)
+}
+
+// Baseliner represents a component that has a baseline, such as a text label.
+// It is used to align items with 'align-items: baseline'.
+// Components that don't implement it are aligned by their bottom edge.
+type Baseliner interface {
+	// Baseline returns the distance from the top of the content to its first
+	// baseline, when the size of the content, excluding padding, is width x height.
+	Baseline(width, height int) int
+}
+
+// Focusable represents a component that can receive the keyboard focus.
+// Views whose handler implements Focusable or ButtonHandler can be focused
+// with Tab, and the focused ButtonHandler is pressed with Enter or Space.
+type Focusable interface {
+	// HandleFocus handles the event when the view gains the focus.
+	HandleFocus()
+	// HandleBlur handles the event when the view loses the focus.
+	HandleBlur()
+}
+
+// KeyHandler represents a component that handles the keyboard.
+// The key events are dispatched to the focused view, or to the root view
+// if no view has the focus, and bubble up to the ancestors until a handler
+// returns true.
+type KeyHandler interface {
+	// HandleKeyDown handles the key just pressed, or held long enough to
+	// repeat, in which case repeat is true. It returns true if it handles the key.
+	HandleKeyDown(key ebiten.Key, mods KeyModifier, repeat bool) bool
+	// HandleKeyUp handles the key just released. It returns true if it handles the key.
+	HandleKeyUp(key ebiten.Key, mods KeyModifier) bool
+}
+
 // DrawHandler represents a component that can be added to a container.
 // Deprectead: use Drawer instead
 type DrawHandler interface {
@@ -37,6 +92,7 @@ type UpdateHandler interface {
 }
 
 // ButtonHandler represents a button component.
+// When buttons are nested, the innermost one under the pointer is pressed.
 type ButtonHandler interface {
 	// HandlePress handle the event when user just started pressing the button
 	// The parameter (x, y) is the location relative to the window (0,0).
@@ -69,6 +125,7 @@ type TouchHandler interface {
 // MouseHandler represents a component that handle mouse move.
 type MouseHandler interface {
 	// HandleMouse handles the mouch move and returns true if it handle the mouse move.
+	// It is called every tick while the cursor is over the view, even if it didn't move.
 	// The parameter (x, y) is the location relative to the window (0,0).
 	HandleMouse(x, y int) bool
 }
@@ -108,6 +165,23 @@ type SwipeHandler interface {
 	HandleSwipe(dir SwipeDirection)
 }
 
+// EventHandler represents a component that handles the pointer events
+// targeted at its view or at its descendants. It is called in the target
+// and the bubble phases of an Event.
+type EventHandler interface {
+	// HandleEvent handles the event. Call e.StopPropagation to stop the
+	// event from bubbling up, or e.PreventDefault to prevent its default action.
+	HandleEvent(e *Event)
+}
+
+// EventCapturer represents a component that intercepts the pointer events
+// targeted at its descendants. It is called in the capture phase of an
+// Event, before the descendants receive it.
+type EventCapturer interface {
+	// CaptureEvent handles the event before the descendants of the view.
+	CaptureEvent(e *Event)
+}
+
 type handler struct {
 	opts HandlerOpts
 }
@@ -148,3 +222,9 @@ func (h *handler) HandleRelease(x, y int, isCancel bool) {
 		h.opts.HandleRelease(x, y, isCancel)
 	}
 }
+
+// IsButton reports whether the handler has a press or release callback,
+// so that draw-only handlers are not pressed nor focused.
+func (h *handler) IsButton() bool {
+	return h.opts.HandlePress != nil || h.opts.HandleRelease != nil
+}
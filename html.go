@@ -82,6 +82,10 @@ Loop:
 				continue
 			}
 			stack.peek().AddChild(view)
+			if voidElements[string(tn)] {
+				// Void elements like <input> have no end tag.
+				continue
+			}
 			stack.push(view)
 
 			depth++
@@ -100,7 +104,9 @@ Loop:
 				inBody = false
 				continue
 			}
-			if !inBody {
+			if !inBody || voidElements[string(tn)] {
+				// The end tags of void elements like </input> are ignored,
+				// as their start tags weren't pushed.
 				continue
 			}
 			stack.pop()
@@ -157,7 +163,7 @@ func (s *stack) pop() *View {
 }
 
 var (
-	defaultComponents   = ComponentsMap{"div": nil, "view": nil}
+	defaultComponents   = ComponentsMap{"div": nil, "view": nil, "input": func() Handler { return &TextInput{} }}
 	registerdComponents = defaultComponents
 )
 
@@ -170,6 +176,9 @@ func RegisterComponents(cs ComponentsMap) {
 func register(name string, c Component) { registerdComponents[name] = c }
 func resetComponents()                  { registerdComponents = defaultComponents }
 
+// voidElements are the elements that have no end tag.
+var voidElements = map[string]bool{"input": true}
+
 type cms []ComponentsMap
 
 func processTag(z *html.Tokenizer, tagName string, opts *ParseOptions, depth int, cms cms) *View {
@@ -183,6 +192,9 @@ func processTag(z *html.Tokenizer, tagName string, opts *ParseOptions, depth int
 	view.Raw = string(z.Raw())
 
 	setStyleProps(view, readAttrs(z))
+	if t, ok := view.Handler.(*TextInput); ok {
+		t.setAttrs(view.Attrs)
+	}
 
 	return view
 }
@@ -193,6 +205,7 @@ func setStyleProps(view *View, attrs attrs) {
 	view.ID = attrs.id
 	view.Attrs = attrs.miscs
 	view.Hidden = attrs.hidden
+	view.TabIndex = attrs.tabIndex
 }
 
 func processRootView(view *View, opts *ParseOptions) {
@@ -261,22 +274,58 @@ func parseStyle(view *View, style string) {
 
 func Int(i int) *int { return &i }
 
+// intValue returns the value of i, or 0 if it is nil.
+func intValue(i *int) int {
+	if i == nil {
+		return 0
+	}
+	return *i
+}
+
 var styleMapper = map[string]mapper[View]{
 	"left": {
-		parseFunc: parseNumber,
-		setFunc:   setFunc(func(v *View, val int) { v.Left = val }),
+		parseFunc: parseInset,
+		setFunc: setFunc(func(v *View, val cssLength) {
+			var left int
+			left, v.LeftInPct, _ = insetValue(val)
+			v.Left = nil
+			if val.unit == cssUnitPx {
+				v.Left = Int(left)
+			}
+		}),
 	},
 	"right": {
-		parseFunc: parseNumber,
-		setFunc:   setFunc(func(v *View, val int) { v.Right = Int(val) }),
+		parseFunc: parseInset,
+		setFunc: setFunc(func(v *View, val cssLength) {
+			var right int
+			right, v.RightInPct, _ = insetValue(val)
+			v.Right = nil
+			if val.unit == cssUnitPx {
+				v.Right = Int(right)
+			}
+		}),
 	},
 	"top": {
-		parseFunc: parseNumber,
-		setFunc:   setFunc(func(v *View, val int) { v.Top = val }),
+		parseFunc: parseInset,
+		setFunc: setFunc(func(v *View, val cssLength) {
+			var top int
+			top, v.TopInPct, _ = insetValue(val)
+			v.Top = nil
+			if val.unit == cssUnitPx {
+				v.Top = Int(top)
+			}
+		}),
 	},
 	"bottom": {
-		parseFunc: parseNumber,
-		setFunc:   setFunc(func(v *View, val int) { v.Bottom = Int(val) }),
+		parseFunc: parseInset,
+		setFunc: setFunc(func(v *View, val cssLength) {
+			var bottom int
+			bottom, v.BottomInPct, _ = insetValue(val)
+			v.Bottom = nil
+			if val.unit == cssUnitPx {
+				v.Bottom = Int(bottom)
+			}
+		}),
 	},
 	"width": {
 		parseFunc: parseLength,
@@ -300,21 +349,126 @@ var styleMapper = map[string]mapper[View]{
 			}
 		}),
 	},
+	"min-width": {
+		parseFunc: parseLength,
+		setFunc: setFunc(func(v *View, val cssLength) {
+			switch val.unit {
+			case cssUnitPx:
+				v.MinWidth = int(val.val)
+			case cssUnitPct:
+				v.MinWidthInPct = val.val
+			}
+		}),
+	},
+	"max-width": {
+		parseFunc: parseLength,
+		setFunc: setFunc(func(v *View, val cssLength) {
+			switch val.unit {
+			case cssUnitPx:
+				v.MaxWidth = int(val.val)
+			case cssUnitPct:
+				v.MaxWidthInPct = val.val
+			}
+		}),
+	},
+	"min-height": {
+		parseFunc: parseLength,
+		setFunc: setFunc(func(v *View, val cssLength) {
+			switch val.unit {
+			case cssUnitPx:
+				v.MinHeight = int(val.val)
+			case cssUnitPct:
+				v.MinHeightInPct = val.val
+			}
+		}),
+	},
+	"max-height": {
+		parseFunc: parseLength,
+		setFunc: setFunc(func(v *View, val cssLength) {
+			switch val.unit {
+			case cssUnitPx:
+				v.MaxHeight = int(val.val)
+			case cssUnitPct:
+				v.MaxHeightInPct = val.val
+			}
+		}),
+	},
+	"aspect-ratio": {
+		parseFunc: parseAspectRatio,
+		setFunc:   setFunc(func(v *View, val float64) { v.AspectRatio = val }),
+	},
+	"margin": {
+		parseFunc: parseMargins,
+		setFunc: setFunc(func(v *View, val sides[cssLength]) {
+			v.MarginTop, v.MarginTopAuto = marginValue(val.top)
+			v.MarginRight, v.MarginRightAuto = marginValue(val.right)
+			v.MarginBottom, v.MarginBottomAuto = marginValue(val.bottom)
+			v.MarginLeft, v.MarginLeftAuto = marginValue(val.left)
+		}),
+	},
 	"margin-left": {
-		parseFunc: parseNumber,
-		setFunc:   setFunc(func(v *View, val int) { v.MarginLeft = val }),
+		parseFunc: parseMargin,
+		setFunc: setFunc(func(v *View, val cssLength) {
+			v.MarginLeft, v.MarginLeftAuto = marginValue(val)
+		}),
 	},
 	"margin-top": {
-		parseFunc: parseNumber,
-		setFunc:   setFunc(func(v *View, val int) { v.MarginTop = val }),
+		parseFunc: parseMargin,
+		setFunc: setFunc(func(v *View, val cssLength) {
+			v.MarginTop, v.MarginTopAuto = marginValue(val)
+		}),
 	},
 	"margin-right": {
-		parseFunc: parseNumber,
-		setFunc:   setFunc(func(v *View, val int) { v.MarginRight = val }),
+		parseFunc: parseMargin,
+		setFunc: setFunc(func(v *View, val cssLength) {
+			v.MarginRight, v.MarginRightAuto = marginValue(val)
+		}),
 	},
 	"margin-bottom": {
+		parseFunc: parseMargin,
+		setFunc: setFunc(func(v *View, val cssLength) {
+			v.MarginBottom, v.MarginBottomAuto = marginValue(val)
+		}),
+	},
+	"padding": {
+		parseFunc: parsePadding,
+		setFunc: setFunc(func(v *View, val sides[int]) {
+			v.PaddingTop = val.top
+			v.PaddingRight = val.right
+			v.PaddingBottom = val.bottom
+			v.PaddingLeft = val.left
+		}),
+	},
+	"padding-left": {
+		parseFunc: parseNumber,
+		setFunc:   setFunc(func(v *View, val int) { v.PaddingLeft = val }),
+	},
+	"padding-top": {
+		parseFunc: parseNumber,
+		setFunc:   setFunc(func(v *View, val int) { v.PaddingTop = val }),
+	},
+	"padding-right": {
+		parseFunc: parseNumber,
+		setFunc:   setFunc(func(v *View, val int) { v.PaddingRight = val }),
+	},
+	"padding-bottom": {
+		parseFunc: parseNumber,
+		setFunc:   setFunc(func(v *View, val int) { v.PaddingBottom = val }),
+	},
+	"gap": {
+		parseFunc: parseGap,
+		setFunc: setFunc(func(v *View, val gap) {
+			v.RowGap = val.row
+			v.ColumnGap = val.column
+		}),
+	},
+	"row-gap": {
+		parseFunc: parseNumber,
+		setFunc:   setFunc(func(v *View, val int) { v.RowGap = val }),
+	},
+	"column-gap": {
 		parseFunc: parseNumber,
-		setFunc:   setFunc(func(v *View, val int) { v.MarginBottom = val }),
+		setFunc:   setFunc(func(v *View, val int) { v.ColumnGap = val }),
 	},
 	"position": {
 		parseFunc: parsePosition,
@@ -348,6 +502,10 @@ var styleMapper = map[string]mapper[View]{
 		parseFunc: parseAlignItem,
 		setFunc:   setFunc(func(v *View, val AlignItem) { v.AlignItems = val }),
 	},
+	"align-self": {
+		parseFunc: parseAlignSelf,
+		setFunc:   setFunc(func(v *View, val AlignSelf) { v.AlignSelf = val }),
+	},
 	"align-content": {
 		parseFunc: parseAlignContent,
 		setFunc:   setFunc(func(v *View, val AlignContent) { v.AlignContent = val }),
@@ -368,10 +526,50 @@ var styleMapper = map[string]mapper[View]{
 		parseFunc: parseFloat,
 		setFunc:   setFunc(func(v *View, val float64) { v.Shrink = val }),
 	},
+	"flex-basis": {
+		parseFunc: parseBasis,
+		setFunc:   setFunc(setBasis),
+	},
+	"flex": {
+		parseFunc: parseFlex,
+		setFunc: setFunc(func(v *View, val flex) {
+			v.Grow = val.grow
+			v.Shrink = val.shrink
+			setBasis(v, val.basis)
+		}),
+	},
+	"order": {
+		parseFunc: parseNumber,
+		setFunc:   setFunc(func(v *View, val int) { v.Order = val }),
+	},
+	"z-index": {
+		parseFunc: parseZIndex,
+		setFunc:   setFunc(func(v *View, val int) { v.ZIndex = val }),
+	},
+	"overflow": {
+		parseFunc: parseOverflow,
+		setFunc:   setFunc(func(v *View, val Overflow) { v.Overflow = val }),
+	},
 	"display": {
 		parseFunc: parseDisplay,
 		setFunc:   setFunc(func(v *View, val Display) { v.Display = val }),
 	},
+	"grid-template-columns": {
+		parseFunc: parseGridTracks,
+		setFunc:   setFunc(func(v *View, val []GridTrack) { v.GridTemplateColumns = val }),
+	},
+	"grid-template-rows": {
+		parseFunc: parseGridTracks,
+		setFunc:   setFunc(func(v *View, val []GridTrack) { v.GridTemplateRows = val }),
+	},
+	"grid-column": {
+		parseFunc: parseGridPlacement,
+		setFunc:   setFunc(func(v *View, val GridPlacement) { v.GridColumn = val }),
+	},
+	"grid-row": {
+		parseFunc: parseGridPlacement,
+		setFunc:   setFunc(func(v *View, val GridPlacement) { v.GridRow = val }),
+	},
 }
 
 // setFunc creates a function that takes an entity and a value as an interface{}.
@@ -434,15 +632,126 @@ func parseNumber(val string) (any, error) {
 	return strconv.Atoi(val)
 }
 
+// sides holds the values of a box shorthand property such as 'padding'.
+type sides[T any] struct {
+	top, right, bottom, left T
+}
+
+// parseSides parses the one to four value syntax of box shorthand properties,
+// e.g. "10px", "10px 20px", "10px 20px 30px" or "10px 20px 30px 40px".
+// Each value is parsed by the given parse function.
+func parseSides[T any](val string, parse func(string) (any, error)) (sides[T], error) {
+	fields := strings.Fields(val)
+	vals := make([]T, len(fields))
+	for i, field := range fields {
+		v, err := parse(field)
+		if err != nil {
+			return sides[T]{}, err
+		}
+		vals[i] = v.(T)
+	}
+	switch len(vals) {
+	case 1:
+		return sides[T]{vals[0], vals[0], vals[0], vals[0]}, nil
+	case 2:
+		return sides[T]{vals[0], vals[1], vals[0], vals[1]}, nil
+	case 3:
+		return sides[T]{vals[0], vals[1], vals[2], vals[1]}, nil
+	case 4:
+		return sides[T]{vals[0], vals[1], vals[2], vals[3]}, nil
+	}
+	return sides[T]{}, fmt.Errorf("invalid number of values: %s", val)
+}
+
+func parsePadding(val string) (any, error) {
+	return parseSides[int](val, parseNumber)
+}
+
+func parseMargins(val string) (any, error) {
+	return parseSides[cssLength](val, parseMargin)
+}
+
+// parseMargin parses a margin value, which is a number or "auto".
+func parseMargin(val string) (any, error) {
+	if val == "auto" {
+		return cssLength{unit: cssUnitAuto}, nil
+	}
+	v, err := parseNumber(val)
+	if err != nil {
+		return cssLength{}, err
+	}
+	return cssLength{unit: cssUnitPx, val: float64(v.(int))}, nil
+}
+
+// marginValue returns the value of the parsed margin and whether it is auto.
+func marginValue(val cssLength) (int, bool) {
+	return int(val.val), val.unit == cssUnitAuto
+}
+
+// gap holds the values of the 'gap' shorthand property.
+type gap struct {
+	row, column int
+}
+
+// parseGap parses the 'gap' shorthand, e.g. "10px" or "10px 20px".
+// The first value is the row gap and the second one is the column gap.
+func parseGap(val string) (any, error) {
+	fields := strings.Fields(val)
+	if len(fields) < 1 || len(fields) > 2 {
+		return gap{}, fmt.Errorf("invalid gap: %s", val)
+	}
+	row, err := parseNumber(fields[0])
+	if err != nil {
+		return gap{}, err
+	}
+	column := row
+	if len(fields) == 2 {
+		column, err = parseNumber(fields[1])
+		if err != nil {
+			return gap{}, err
+		}
+	}
+	return gap{row: row.(int), column: column.(int)}, nil
+}
+
 func parseFloat(val string) (any, error) {
 	return strconv.ParseFloat(val, 64)
 }
 
+// parseAspectRatio parses the 'aspect-ratio' property.
+// e.g. "auto", "1.5", "16 / 9".
+func parseAspectRatio(val string) (any, error) {
+	if val == "auto" {
+		return 0., nil
+	}
+	w, h, found := strings.Cut(val, "/")
+	ratio, err := strconv.ParseFloat(strings.TrimSpace(w), 64)
+	if err != nil {
+		return 0., err
+	}
+	if found {
+		d, err := strconv.ParseFloat(strings.TrimSpace(h), 64)
+		if err != nil {
+			return 0., err
+		}
+		if d == 0 {
+			return 0., fmt.Errorf("invalid aspect-ratio: %s", val)
+		}
+		ratio /= d
+	}
+	if ratio < 0 {
+		return 0., fmt.Errorf("invalid aspect-ratio: %s", val)
+	}
+	return ratio, nil
+}
+
 func parsePosition(val string) (any, error) {
 	switch val {
 	case "absolute":
 		return PositionAbsolute, nil
-	case "static", "relative":
+	case "relative":
+		return PositionRelative, nil
+	case "static":
 		return PositionStatic, nil
 	}
 	return PositionStatic, fmt.Errorf("unknown position: %s", val)
@@ -454,6 +763,10 @@ func parseDirection(val string) (any, error) {
 		return Row, nil
 	case "column":
 		return Column, nil
+	case "row-reverse":
+		return RowReverse, nil
+	case "column-reverse":
+		return ColumnReverse, nil
 	}
 	return Column, fmt.Errorf("unknown direction: %s", val)
 }
@@ -464,6 +777,8 @@ func parseWrap(val string) (any, error) {
 		return Wrap, nil
 	case "nowrap":
 		return NoWrap, nil
+	case "wrap-reverse":
+		return WrapReverse, nil
 	}
 	return NoWrap, fmt.Errorf("unknown wrap: %s", val)
 }
@@ -478,6 +793,8 @@ func parseJustify(val string) (any, error) {
 		return JustifySpaceBetween, nil
 	case "space-around":
 		return JustifySpaceAround, nil
+	case "space-evenly":
+		return JustifySpaceEvenly, nil
 	case "center":
 		return JustifyCenter, nil
 	}
@@ -494,10 +811,30 @@ func parseAlignItem(val string) (any, error) {
 		return AlignItemCenter, nil
 	case "stretch":
 		return AlignItemStretch, nil
+	case "baseline":
+		return AlignItemBaseline, nil
 	}
 	return AlignItemStretch, fmt.Errorf("unknown align-items: %s", val)
 }
 
+func parseAlignSelf(val string) (any, error) {
+	switch val {
+	case "auto":
+		return AlignSelfAuto, nil
+	case "flex-start", "start":
+		return AlignSelfStart, nil
+	case "flex-end", "end":
+		return AlignSelfEnd, nil
+	case "center":
+		return AlignSelfCenter, nil
+	case "stretch":
+		return AlignSelfStretch, nil
+	case "baseline":
+		return AlignSelfBaseline, nil
+	}
+	return AlignSelfAuto, fmt.Errorf("unknown align-self: %s", val)
+}
+
 func parseAlignContent(val string) (any, error) {
 	switch val {
 	case "flex-start", "start":
@@ -512,20 +849,251 @@ func parseAlignContent(val string) (any, error) {
 		return AlignContentSpaceBetween, nil
 	case "space-around":
 		return AlignContentSpaceAround, nil
+	case "space-evenly":
+		return AlignContentSpaceEvenly, nil
 	}
 	return AlignContentStart, fmt.Errorf("unknown align-content: %s", val)
 }
 
+func setBasis(v *View, val cssLength) {
+	v.Basis = nil
+	v.BasisInPct = 0
+	switch val.unit {
+	case cssUnitPx:
+		v.Basis = Int(int(val.val))
+	case cssUnitPct:
+		v.BasisInPct = val.val
+	}
+}
+
+// parseInset parses the 'left', 'right', 'top' and 'bottom' properties.
+func parseInset(val string) (any, error) {
+	if val == "auto" {
+		return cssLength{unit: cssUnitAuto}, nil
+	}
+	return parseLength(val)
+}
+
+// insetValue returns the inset in pixels and in percent,
+// and false if it is 'auto'.
+func insetValue(val cssLength) (px int, pct float64, ok bool) {
+	switch val.unit {
+	case cssUnitPx:
+		return int(val.val), 0, true
+	case cssUnitPct:
+		return 0, val.val, true
+	}
+	return 0, 0, false
+}
+
+func parseBasis(val string) (any, error) {
+	switch val {
+	case "auto", "content":
+		return cssLength{unit: cssUnitAuto}, nil
+	}
+	return parseLength(val)
+}
+
+// flex holds the values of the 'flex' shorthand property.
+type flex struct {
+	grow, shrink float64
+	basis        cssLength
+}
+
+// parseFlex parses the 'flex' shorthand property.
+// e.g. "none", "auto", "1", "1 1 0", "2 1 120px", "1 30%".
+func parseFlex(val string) (any, error) {
+	switch val {
+	case "none":
+		return flex{grow: 0, shrink: 0, basis: cssLength{unit: cssUnitAuto}}, nil
+	case "auto":
+		return flex{grow: 1, shrink: 1, basis: cssLength{unit: cssUnitAuto}}, nil
+	case "initial":
+		return flex{grow: 0, shrink: 1, basis: cssLength{unit: cssUnitAuto}}, nil
+	}
+
+	// When omitted, the grow and shrink factors default to 1
+	// and the flex basis defaults to 0.
+	ret := flex{grow: 1, shrink: 1, basis: cssLength{unit: cssUnitPx}}
+	fields := strings.Fields(val)
+	if len(fields) < 1 || len(fields) > 3 {
+		return ret, fmt.Errorf("invalid flex: %s", val)
+	}
+	numbers := 0
+	for i, field := range fields {
+		// Unitless numbers are flex factors unless it's the last of three values.
+		if n, err := strconv.ParseFloat(field, 64); err == nil && numbers < 2 && i < 2 {
+			if numbers == 0 {
+				ret.grow = n
+			} else {
+				ret.shrink = n
+			}
+			numbers++
+			continue
+		}
+		if i != len(fields)-1 {
+			return ret, fmt.Errorf("invalid flex: %s", val)
+		}
+		basis, err := parseBasis(field)
+		if err != nil {
+			return ret, err
+		}
+		ret.basis = basis.(cssLength)
+	}
+	return ret, nil
+}
+
+func parseZIndex(val string) (any, error) {
+	if val == "auto" {
+		return 0, nil
+	}
+	return strconv.Atoi(val)
+}
+
+func parseOverflow(val string) (any, error) {
+	switch val {
+	case "visible":
+		return OverflowVisible, nil
+	case "hidden", "clip":
+		return OverflowHidden, nil
+	case "scroll", "auto":
+		return OverflowScroll, nil
+	}
+	return OverflowVisible, fmt.Errorf("unknown overflow: %s", val)
+}
+
 func parseDisplay(val string) (any, error) {
 	switch val {
 	case "none":
 		return DisplayNone, nil
 	case "", "flex":
 		return DisplayFlex, nil
+	case "grid":
+		return DisplayGrid, nil
 	}
 	return DisplayFlex, fmt.Errorf("unknown display: %s", val)
 }
 
+// parseGridTracks parses a track list such as "100px 1fr auto",
+// "repeat(3, 1fr)" or "none".
+func parseGridTracks(val string) (any, error) {
+	if val == "none" {
+		return []GridTrack(nil), nil
+	}
+	var tracks []GridTrack
+	for _, field := range splitGridTracks(val) {
+		if strings.HasPrefix(field, "repeat(") && strings.HasSuffix(field, ")") {
+			count, list, found := strings.Cut(field[len("repeat("):len(field)-1], ",")
+			n, err := strconv.Atoi(strings.TrimSpace(count))
+			if !found || err != nil || n < 1 {
+				return []GridTrack(nil), fmt.Errorf("invalid grid track repeat: %s", field)
+			}
+			repeated, err := parseGridTracks(strings.TrimSpace(list))
+			if err != nil {
+				return []GridTrack(nil), err
+			}
+			for i := 0; i < n; i++ {
+				tracks = append(tracks, repeated.([]GridTrack)...)
+			}
+			continue
+		}
+		track, err := parseGridTrack(field)
+		if err != nil {
+			return []GridTrack(nil), err
+		}
+		tracks = append(tracks, track)
+	}
+	return tracks, nil
+}
+
+// splitGridTracks splits a track list by spaces outside of parentheses.
+func splitGridTracks(val string) []string {
+	var fields []string
+	depth, start := 0, -1
+	for i, r := range val {
+		switch {
+		case r == '(':
+			depth++
+		case r == ')':
+			depth--
+		case r == ' ' && depth == 0:
+			if start >= 0 {
+				fields = append(fields, val[start:i])
+				start = -1
+			}
+			continue
+		}
+		if start < 0 {
+			start = i
+		}
+	}
+	if start >= 0 {
+		fields = append(fields, val[start:])
+	}
+	return fields
+}
+
+func parseGridTrack(val string) (GridTrack, error) {
+	unit := GridTrackPx
+	switch {
+	case val == "auto":
+		return GridTrack{Unit: GridTrackAuto}, nil
+	case strings.HasSuffix(val, "fr"):
+		unit, val = GridTrackFr, strings.TrimSuffix(val, "fr")
+	case strings.HasSuffix(val, "%"):
+		unit, val = GridTrackPct, strings.TrimSuffix(val, "%")
+	default:
+		val = strings.TrimSuffix(val, "px")
+	}
+	size, err := strconv.ParseFloat(val, 64)
+	if err != nil || size < 0 {
+		return GridTrack{}, fmt.Errorf("invalid grid track: %s", val)
+	}
+	return GridTrack{Size: size, Unit: unit}, nil
+}
+
+// parseGridPlacement parses the placement of a grid item such as "2",
+// "1 / 3", "1 / -1", "span 2" or "2 / span 2".
+func parseGridPlacement(val string) (any, error) {
+	var p GridPlacement
+	start, end, found := strings.Cut(val, "/")
+	line, span, err := parseGridLine(strings.TrimSpace(start))
+	if err != nil {
+		return p, err
+	}
+	p.Start, p.Span = line, span
+	if found {
+		line, span, err := parseGridLine(strings.TrimSpace(end))
+		if err != nil {
+			return p, err
+		}
+		p.End = line
+		if span != 0 {
+			p.Span = span
+		}
+	}
+	return p, nil
+}
+
+// parseGridLine parses a grid line, "span <n>" or "auto".
+func parseGridLine(val string) (line, span int, err error) {
+	if val == "auto" {
+		return 0, 0, nil
+	}
+	if strings.HasPrefix(val, "span") {
+		span, err = strconv.Atoi(strings.TrimSpace(strings.TrimPrefix(val, "span")))
+		if err != nil || span < 1 {
+			return 0, 0, fmt.Errorf("invalid grid span: %s", val)
+		}
+		return 0, span, nil
+	}
+	line, err = strconv.Atoi(val)
+	if err != nil || line == 0 {
+		return 0, 0, fmt.Errorf("invalid grid line: %s", val)
+	}
+	return line, 0, nil
+}
+
 type cssLength struct {
 	unit cssUnit
 	val  float64
@@ -551,10 +1119,11 @@ func parseLength(val string) (any, error) {
 }
 
 type attrs struct {
-	id     string
-	style  string
-	hidden bool
-	miscs  map[string]string
+	id       string
+	style    string
+	hidden   bool
+	tabIndex int
+	miscs    map[string]string
 }
 
 func readAttrs(z *html.Tokenizer) attrs {
@@ -576,6 +1145,9 @@ func readAttrs(z *html.Tokenizer) attrs {
 			} else {
 				attr.hidden = parseBool(v)
 			}
+		case "tabindex":
+			// Invalid values are ignored, as in HTML.
+			attr.tabIndex, _ = strconv.Atoi(strings.TrimSpace(string(val)))
 		}
 		if !more {
 			break
@@ -593,4 +1165,5 @@ type cssUnit int
 const (
 	cssUnitPx cssUnit = iota
 	cssUnitPct
+	cssUnitAuto
 )
@@ -41,8 +41,8 @@ func TestParseHTML(t *testing.T) {
 					</view>
 				</body>`,
 			expected: (&View{
-				Left:         50,
-				Top:          100,
+				Left:         Int(50),
+				Top:          Int(100),
 				Width:        200,
 				Height:       300,
 				MarginLeft:   120,
@@ -185,6 +185,60 @@ func TestParseHTML(t *testing.T) {
 				require.Equal(t, true, elem.Hidden)
 			},
 		},
+		{
+			name: "tabindex attribute",
+			html: `
+				<view>
+					<view id="first" tabindex="1"></view>
+					<view id="skipped" tabindex="-1"></view>
+					<view id="invalid" tabindex="x"></view>
+				</view>`,
+			expected: (&View{}).AddChild(&View{}, &View{}, &View{}),
+			after: func(t *testing.T, v *View) {
+				for id, want := range map[string]int{"first": 1, "skipped": -1, "invalid": 0} {
+					elem, ok := v.GetByID(id)
+					require.True(t, ok)
+					require.Equal(t, want, elem.TabIndex, id)
+				}
+			},
+		},
+		{
+			name: "input element",
+			html: `
+				<view>
+					<input id="name" value="furex" placeholder="Name" maxlength="8">
+					<input id="password" type="password"/>
+					<input id="long" value="hello world" maxlength="3">
+					<view id="after"></view>
+				</view>`,
+			expected: (&View{}).AddChild(&View{}, &View{}, &View{}, &View{}),
+			after: func(t *testing.T, v *View) {
+				name := v.MustGetByID("name").Handler.(*TextInput)
+				require.Equal(t, "furex", name.Value)
+				require.Equal(t, "Name", name.Placeholder)
+				require.Equal(t, 8, name.MaxLength)
+				require.False(t, name.Password)
+
+				password := v.MustGetByID("password").Handler.(*TextInput)
+				require.True(t, password.Password)
+				require.NotSame(t, name, password)
+
+				long := v.MustGetByID("long").Handler.(*TextInput)
+				require.Equal(t, "hel", long.Value, "the value is cut to the max length")
+			},
+		},
+		{
+			name: "input end tag",
+			html: `<div><input id="name"></input><span id="span"></span></div>`,
+			opts: &ParseOptions{
+				Components: map[string]Component{"span": nil},
+			},
+			expected: (&View{}).AddChild(&View{}, &View{}),
+			after: func(t *testing.T, v *View) {
+				require.Equal(t, v, v.MustGetByID("name").parent)
+				require.Equal(t, v, v.MustGetByID("span").parent)
+			},
+		},
 		{
 			name: "complex",
 			html: `
@@ -459,15 +513,15 @@ func TestParseHTML(t *testing.T) {
 						),
 						(&View{
 							Position: PositionAbsolute,
-							Left:     300 - 35/2,
-							Top:      4 - 38/2,
+							Left:     Int(300 - 35/2),
+							Top:      Int(4 - 38/2),
 							Width:    35,
 							Height:   38,
 						}).AddChild(
 							&View{
 								Position: PositionAbsolute,
-								Left:     18,
-								Top:      17,
+								Left:     Int(18),
+								Top:      Int(17),
 							},
 						),
 					),
@@ -532,6 +586,219 @@ func TestParseHTML(t *testing.T) {
 					},
 				),
 			)},
+		{
+			name: "padding",
+			html: `
+				<view style="padding: 10px 20px">
+					<view style="padding: 1 2 3 4; padding-left: 5px"></view>
+					<view style="padding-top: 6; padding-right: 7; padding-bottom: 8"></view>
+				</view>`,
+			expected: (&View{
+				PaddingTop:    10,
+				PaddingRight:  20,
+				PaddingBottom: 10,
+				PaddingLeft:   20,
+			}).AddChild(
+				&View{
+					PaddingTop:    1,
+					PaddingRight:  2,
+					PaddingBottom: 3,
+					PaddingLeft:   5,
+				},
+				&View{
+					PaddingTop:    6,
+					PaddingRight:  7,
+					PaddingBottom: 8,
+				},
+			),
+		},
+		{
+			name: "gap",
+			html: `
+				<view style="gap: 10px 20px">
+					<view style="gap: 5"></view>
+					<view style="row-gap: 6px; column-gap: 7px"></view>
+				</view>`,
+			expected: (&View{
+				RowGap:    10,
+				ColumnGap: 20,
+			}).AddChild(
+				&View{RowGap: 5, ColumnGap: 5},
+				&View{RowGap: 6, ColumnGap: 7},
+			),
+		},
+		{
+			name: "min and max size",
+			html: `
+				<view style="min-width: 48px; max-width: 400px; min-height: 10%; max-height: 50%">
+				</view>`,
+			expected: &View{
+				MinWidth:       48,
+				MaxWidth:       400,
+				MinHeightInPct: 10,
+				MaxHeightInPct: 50,
+			},
+		},
+		{
+			name: "insets",
+			html: `
+				<view style="position: absolute; left: 0; right: 10%; top: 25%; bottom: auto">
+					<view style="left: 5px; bottom: 0"></view>
+				</view>`,
+			expected: (&View{Position: PositionAbsolute, Left: Int(0), RightInPct: 10, TopInPct: 25}).AddChild(
+				&View{Left: Int(5), Bottom: Int(0)},
+			),
+		},
+		{
+			name: "relative position",
+			html: `
+				<view style="position: relative; top: 4px">
+				</view>`,
+			expected: &View{Position: PositionRelative, Top: Int(4)},
+		},
+		{
+			name: "z-index",
+			html: `
+				<view style="z-index: 10">
+					<view style="z-index: -1"></view>
+					<view style="z-index: auto"></view>
+				</view>`,
+			expected: (&View{ZIndex: 10}).AddChild(
+				&View{ZIndex: -1},
+				&View{},
+			),
+		},
+		{
+			name: "overflow",
+			html: `
+				<view style="overflow: hidden">
+					<view style="overflow: scroll"></view>
+					<view style="overflow: visible"></view>
+				</view>`,
+			expected: (&View{Overflow: OverflowHidden}).AddChild(
+				&View{Overflow: OverflowScroll},
+				&View{},
+			),
+		},
+		{
+			name: "aspect ratio",
+			html: `
+				<view style="aspect-ratio: 16 / 9">
+					<view style="aspect-ratio: 1.5"></view>
+					<view style="aspect-ratio: auto"></view>
+				</view>`,
+			expected: (&View{AspectRatio: 16. / 9}).AddChild(
+				&View{AspectRatio: 1.5},
+				&View{},
+			),
+		},
+		{
+			name: "flex basis and shorthand",
+			html: `
+				<view style="flex-basis: 120px">
+					<view style="flex-basis: 30%"></view>
+					<view style="flex-basis: auto"></view>
+					<view style="flex: 1"></view>
+					<view style="flex: none"></view>
+					<view style="flex: auto"></view>
+					<view style="flex: 2 1 120px"></view>
+					<view style="flex: 1 0"></view>
+					<view style="flex: 50%"></view>
+				</view>`,
+			expected: (&View{Basis: Int(120)}).AddChild(
+				&View{BasisInPct: 30},
+				&View{},
+				&View{Grow: 1, Shrink: 1, Basis: Int(0)},
+				&View{},
+				&View{Grow: 1, Shrink: 1},
+				&View{Grow: 2, Shrink: 1, Basis: Int(120)},
+				&View{Grow: 1, Basis: Int(0)},
+				&View{Grow: 1, Shrink: 1, BasisInPct: 50},
+			),
+		},
+		{
+			name: "align-self",
+			html: `
+				<view style="align-items: center">
+					<view style="align-self: stretch"></view>
+					<view style="align-self: flex-end"></view>
+					<view style="align-self: auto"></view>
+				</view>`,
+			expected: (&View{AlignItems: AlignItemCenter}).AddChild(
+				&View{AlignSelf: AlignSelfStretch},
+				&View{AlignSelf: AlignSelfEnd},
+				&View{},
+			),
+		},
+		{
+			name: "baseline",
+			html: `
+				<view style="align-items: baseline">
+					<view style="align-self: baseline"></view>
+				</view>`,
+			expected: (&View{AlignItems: AlignItemBaseline}).AddChild(
+				&View{AlignSelf: AlignSelfBaseline},
+			),
+		},
+		{
+			name: "reverse",
+			html: `
+				<view style="flex-direction: row-reverse; flex-wrap: wrap-reverse">
+					<view style="flex-direction: column-reverse"></view>
+				</view>`,
+			expected: (&View{Direction: RowReverse, Wrap: WrapReverse}).AddChild(
+				&View{Direction: ColumnReverse},
+			),
+		},
+		{
+			name: "auto margins",
+			html: `
+				<view style="margin: 0 auto">
+					<view style="margin-left: auto; margin-top: 10px"></view>
+					<view style="margin: 1 2 3 auto"></view>
+				</view>`,
+			expected: (&View{MarginLeftAuto: true, MarginRightAuto: true}).AddChild(
+				&View{MarginLeftAuto: true, MarginTop: 10},
+				&View{MarginTop: 1, MarginRight: 2, MarginBottom: 3, MarginLeftAuto: true},
+			),
+		},
+		{
+			name: "space-evenly",
+			html: `
+				<view style="justify-content: space-evenly; align-content: space-evenly">
+				</view>`,
+			expected: &View{
+				Justify:      JustifySpaceEvenly,
+				AlignContent: AlignContentSpaceEvenly,
+			},
+		},
+		{
+			name: "grid",
+			html: `
+				<view style="display: grid; grid-template-columns: 100px repeat(2, 1fr 20%); grid-template-rows: auto 50">
+					<view style="grid-column: 1 / -1"></view>
+					<view style="grid-column: span 2; grid-row: 2"></view>
+					<view style="grid-column: 2 / span 3; grid-row: auto"></view>
+				</view>`,
+			expected: (&View{
+				Display: DisplayGrid,
+				GridTemplateColumns: []GridTrack{
+					{Size: 100, Unit: GridTrackPx},
+					{Size: 1, Unit: GridTrackFr},
+					{Size: 20, Unit: GridTrackPct},
+					{Size: 1, Unit: GridTrackFr},
+					{Size: 20, Unit: GridTrackPct},
+				},
+				GridTemplateRows: []GridTrack{
+					{Unit: GridTrackAuto},
+					{Size: 50, Unit: GridTrackPx},
+				},
+			}).AddChild(
+				&View{GridColumn: GridPlacement{Start: 1, End: -1}},
+				&View{GridColumn: GridPlacement{Span: 2}, GridRow: GridPlacement{Start: 2}},
+				&View{GridColumn: GridPlacement{Start: 2, Span: 3}},
+			),
+		},
 		{
 			name: "functional component",
 			before: func(t *testing.T) {
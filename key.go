@@ -0,0 +1,109 @@
+package furex
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+)
+
+// KeyModifier is a set of the modifier keys held during a key event.
+type KeyModifier uint8
+
+const (
+	KeyModifierShift KeyModifier = 1 << iota
+	KeyModifierControl
+	KeyModifierAlt
+	KeyModifierMeta
+)
+
+// Has reports whether the set has all the modifiers m.
+func (mods KeyModifier) Has(m KeyModifier) bool {
+	return mods&m == m
+}
+
+const (
+	// keyRepeatDelay is the number of ticks a key is held before it repeats.
+	keyRepeatDelay = 30
+	// keyRepeatInterval is the number of ticks between the repeats of a key.
+	keyRepeatInterval = 3
+)
+
+func currentModifiers() KeyModifier {
+	var mods KeyModifier
+	if ebiten.IsKeyPressed(ebiten.KeyShift) {
+		mods |= KeyModifierShift
+	}
+	if ebiten.IsKeyPressed(ebiten.KeyControl) {
+		mods |= KeyModifierControl
+	}
+	if ebiten.IsKeyPressed(ebiten.KeyAlt) {
+		mods |= KeyModifierAlt
+	}
+	if ebiten.IsKeyPressed(ebiten.KeyMeta) {
+		mods |= KeyModifierMeta
+	}
+	return mods
+}
+
+// keyTarget returns the view that receives the key events of the tree:
+// the focused view, or the root if no view has the focus.
+func (v *View) keyTarget() *View {
+	if f := v.Focused(); f != nil {
+		return f
+	}
+	return v.root()
+}
+
+// dispatchKeyDown dispatches the key down event to the KeyHandler of the
+// target view, and then to its ancestors until one handles it.
+// It reports whether the event was handled.
+func (v *View) dispatchKeyDown(key ebiten.Key, mods KeyModifier, repeat bool) bool {
+	t := v.keyTarget()
+	for {
+		if h, ok := t.Handler.(KeyHandler); ok && h.HandleKeyDown(key, mods, repeat) {
+			return true
+		}
+		if !t.hasParent {
+			return false
+		}
+		t = t.parent
+	}
+}
+
+// dispatchKeyUp is the sibling of dispatchKeyDown for the key up event.
+func (v *View) dispatchKeyUp(key ebiten.Key, mods KeyModifier) bool {
+	t := v.keyTarget()
+	for {
+		if h, ok := t.Handler.(KeyHandler); ok && h.HandleKeyUp(key, mods) {
+			return true
+		}
+		if !t.hasParent {
+			return false
+		}
+		t = t.parent
+	}
+}
+
+// handleKeyEvents dispatches the key events of this tick, and returns the
+// keys whose down event was handled, so that the keys for the focus and
+// the navigation are left to the handlers that use them.
+func (v *View) handleKeyEvents() map[ebiten.Key]bool {
+	mods := currentModifiers()
+	var handled map[ebiten.Key]bool
+	for _, k := range inpututil.AppendPressedKeys(nil) {
+		d := inpututil.KeyPressDuration(k)
+		repeat := d > keyRepeatDelay && (d-keyRepeatDelay)%keyRepeatInterval == 0
+		if d != 1 && !repeat {
+			continue
+		}
+		if v.dispatchKeyDown(k, mods, repeat) {
+			if handled == nil {
+				handled = map[ebiten.Key]bool{}
+			}
+			handled[k] = true
+		}
+	}
+	for _, k := range inpututil.AppendJustReleasedKeys(nil) {
+		v.dispatchKeyUp(k, mods)
+	}
+	return handled
+}
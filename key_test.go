@@ -0,0 +1,69 @@
+package furex
+
+import (
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/stretchr/testify/require"
+)
+
+type keyEvent struct {
+	key    ebiten.Key
+	mods   KeyModifier
+	repeat bool
+	up     bool
+}
+
+type keyHandler struct {
+	focusHandler
+	handles map[ebiten.Key]bool
+	events  []keyEvent
+}
+
+func (h *keyHandler) HandleKeyDown(key ebiten.Key, mods KeyModifier, repeat bool) bool {
+	h.events = append(h.events, keyEvent{key: key, mods: mods, repeat: repeat})
+	return h.handles[key]
+}
+
+func (h *keyHandler) HandleKeyUp(key ebiten.Key, mods KeyModifier) bool {
+	h.events = append(h.events, keyEvent{key: key, mods: mods, up: true})
+	return h.handles[key]
+}
+
+func TestKeyDispatch(t *testing.T) {
+	rootHandler := &keyHandler{handles: map[ebiten.Key]bool{ebiten.KeyEscape: true}}
+	panelHandler := &keyHandler{handles: map[ebiten.Key]bool{ebiten.KeyA: true}}
+	fieldHandler := &keyHandler{handles: map[ebiten.Key]bool{ebiten.KeyB: true}}
+	field := &View{Handler: fieldHandler}
+	panel := (&View{Handler: panelHandler}).AddChild(&View{}).AddChild(field)
+	root := (&View{Width: 100, Height: 100, Handler: rootHandler}).AddChild(panel)
+
+	require.False(t, root.dispatchKeyDown(ebiten.KeyB, 0, false))
+	require.Equal(t, []keyEvent{{key: ebiten.KeyB}}, rootHandler.events,
+		"the root receives the keys when no view is focused")
+	require.Empty(t, fieldHandler.events)
+
+	rootHandler.events = nil
+	field.Focus()
+	require.True(t, root.dispatchKeyDown(ebiten.KeyB, KeyModifierShift, true))
+	require.Equal(t, []keyEvent{{key: ebiten.KeyB, mods: KeyModifierShift, repeat: true}}, fieldHandler.events)
+	require.Empty(t, panelHandler.events, "handled keys don't bubble up")
+
+	fieldHandler.events = nil
+	require.True(t, root.dispatchKeyUp(ebiten.KeyA, KeyModifierControl|KeyModifierAlt))
+	require.Equal(t, []keyEvent{{key: ebiten.KeyA, mods: KeyModifierControl | KeyModifierAlt, up: true}}, fieldHandler.events)
+	require.Equal(t, []keyEvent{{key: ebiten.KeyA, mods: KeyModifierControl | KeyModifierAlt, up: true}}, panelHandler.events)
+	require.Empty(t, rootHandler.events)
+
+	require.True(t, root.dispatchKeyDown(ebiten.KeyEscape, 0, false))
+	require.Len(t, rootHandler.events, 1, "unhandled keys bubble up to the root")
+	require.False(t, root.dispatchKeyDown(ebiten.KeyC, 0, false))
+}
+
+func TestKeyModifier(t *testing.T) {
+	mods := KeyModifierShift | KeyModifierMeta
+	require.True(t, mods.Has(KeyModifierShift))
+	require.True(t, mods.Has(KeyModifierShift|KeyModifierMeta))
+	require.False(t, mods.Has(KeyModifierShift|KeyModifierControl))
+	require.False(t, mods.Has(KeyModifierAlt))
+}
@@ -0,0 +1,217 @@
+package furex
+
+import (
+	"sort"
+)
+
+// VirtualList is a handler that shows a long list of rows in its view,
+// keeping only the rows around the visible area instantiated.
+// The rows leaving the visible area are recycled for the rows entering it.
+//
+// The list lays out its rows in a column, and owns the children of the view.
+// Set the overflow of the view to OverflowScroll to make it scrollable:
+//
+//	list := &furex.View{
+//		Width:    300,
+//		Height:   400,
+//		Overflow: furex.OverflowScroll,
+//		Handler: &furex.VirtualList{
+//			RowCount:  len(items),
+//			RowHeight: 40,
+//			NewRow:    func() *furex.View { return &furex.View{Handler: &Label{}} },
+//			BindRow: func(row *furex.View, index int) {
+//				row.Handler.(*Label).Text = items[index]
+//			},
+//		},
+//	}
+type VirtualList struct {
+	// RowCount is the number of rows in the list.
+	RowCount int
+	// RowHeight is the height of each row.
+	RowHeight int
+	// MeasureRow returns the height of the row at the index.
+	// If it is set, it is used instead of RowHeight.
+	MeasureRow func(index int) int
+	// NewRow creates a view for a row. The view is reused for other rows
+	// once it is scrolled out.
+	NewRow func() *View
+	// BindRow updates the row view to show the row at the index.
+	BindRow func(row *View, index int)
+	// Overscan is the number of rows kept beyond each edge of the visible area.
+	Overscan int
+
+	view       *View
+	rows       map[int]*View // the instantiated rows by their index
+	pool       []*View
+	first      int
+	last       int
+	offsets    []int // the top of each row, and the bottom of the last one
+	head, tail *View // the spacers for the rows that aren't instantiated
+}
+
+var _ Updater = (*VirtualList)(nil)
+
+// Update instantiates the rows in the visible area of the view.
+func (l *VirtualList) Update(v *View) {
+	if l.view != v {
+		l.view = v
+		l.rows = nil
+	}
+	if l.rows == nil {
+		l.rows = map[int]*View{}
+		l.head, l.tail = &View{Basis: Int(0)}, &View{Basis: Int(0)}
+		l.first, l.last = 0, 0
+		v.RemoveAll()
+		v.AddChild(l.head, l.tail)
+	}
+	if v.Direction != Column {
+		v.SetDirection(Column)
+	}
+	if len(l.offsets) != l.RowCount+1 {
+		l.measure()
+	}
+	first, last := l.visibleRange(v)
+	if first == l.first && last == l.last {
+		return
+	}
+	l.bind(first, last, false)
+}
+
+// Refresh rebinds the visible rows, e.g. when the data of the rows or
+// the row count changed, and measures the rows again.
+func (l *VirtualList) Refresh() {
+	if l.view == nil {
+		return
+	}
+	l.measure()
+	first, last := l.visibleRange(l.view)
+	l.bind(first, last, true)
+}
+
+// VisibleRows returns the range [first, last) of the rows instantiated.
+func (l *VirtualList) VisibleRows() (first, last int) {
+	return l.first, l.last
+}
+
+// ScrollToRow scrolls the view of the list so that the row at the index
+// is at the top.
+func (l *VirtualList) ScrollToRow(index int) {
+	if l.view == nil {
+		return
+	}
+	if len(l.offsets) != l.RowCount+1 {
+		l.measure()
+	}
+	index = clampInt(index, 0, l.RowCount)
+	l.view.ScrollTo(l.view.ScrollOffset().X, l.offsets[index])
+}
+
+func (l *VirtualList) rowHeight(index int) int {
+	if l.MeasureRow != nil {
+		return l.MeasureRow(index)
+	}
+	return l.RowHeight
+}
+
+func (l *VirtualList) measure() {
+	l.offsets = make([]int, l.RowCount+1)
+	for i := 0; i < l.RowCount; i++ {
+		l.offsets[i+1] = l.offsets[i] + l.rowHeight(i)
+	}
+}
+
+// visibleRange returns the range of the rows that overlap the visible area
+// of the view, extended by the overscan.
+func (l *VirtualList) visibleRange(v *View) (first, last int) {
+	top := round(v.scroll.y) - v.PaddingTop
+	bottom := top + v.frame.Dy()
+	first = sort.Search(l.RowCount, func(i int) bool { return l.offsets[i+1] > top })
+	last = sort.Search(l.RowCount, func(i int) bool { return l.offsets[i] >= bottom })
+	first = clampInt(first-l.Overscan, 0, l.RowCount)
+	last = clampInt(last+l.Overscan, first, l.RowCount)
+	return first, last
+}
+
+// bind instantiates the rows in [first, last), recycling the other rows.
+// If all is true, the rows that were already instantiated are bound again.
+func (l *VirtualList) bind(first, last int, all bool) {
+	for i, row := range l.rows {
+		if i < first || i >= last {
+			delete(l.rows, i)
+			l.pool = append(l.pool, row)
+		}
+	}
+	rows := make([]*View, 0, last-first+2)
+	rows = append(rows, l.head)
+	for i := first; i < last; i++ {
+		row, ok := l.rows[i]
+		if !ok {
+			if n := len(l.pool); n > 0 {
+				row, l.pool = l.pool[n-1], l.pool[:n-1]
+			} else {
+				row = l.NewRow()
+			}
+			l.rows[i] = row
+		}
+		if !ok || all {
+			if l.BindRow != nil {
+				l.BindRow(row, i)
+			}
+		}
+		if h := l.offsets[i+1] - l.offsets[i]; row.Height != h {
+			row.SetHeight(h)
+		}
+		rows = append(rows, row)
+	}
+	rows = append(rows, l.tail)
+	l.first, l.last = first, last
+
+	setSpacer(l.head, l.offsets[first])
+	setSpacer(l.tail, l.offsets[l.RowCount]-l.offsets[last])
+	l.view.setChildren(rows)
+}
+
+// setSpacer sets the height of a spacer by its basis, which unlike a zero
+// height isn't taken for an unset one.
+func setSpacer(v *View, height int) {
+	if v.Basis == nil || *v.Basis != height {
+		v.SetBasis(height)
+	}
+}
+
+// setChildren replaces the children of the view, keeping the state
+// of the children that remain.
+func (v *View) setChildren(views []*View) {
+	existing := make(map[*View]*child, len(v.children))
+	for _, c := range v.children {
+		existing[c.item] = c
+	}
+	children := make([]*child, 0, len(views))
+	for _, cv := range views {
+		c, ok := existing[cv]
+		if !ok {
+			c = &child{item: cv, handledTouchID: -1}
+			cv.hasParent = true
+			cv.parent = v
+			cv.markDirty()
+		}
+		delete(existing, cv)
+		children = append(children, c)
+	}
+	for cv := range existing {
+		cv.hasParent = false
+		cv.parent = nil
+	}
+	v.children = children
+	v.markDirty()
+}
+
+func clampInt(v, min, max int) int {
+	if v < min {
+		return min
+	}
+	if v > max {
+		return max
+	}
+	return v
+}
@@ -0,0 +1,130 @@
+package furex
+
+import (
+	"image"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type listRow struct {
+	mockHandler
+	index int
+	binds int
+}
+
+func newTestList(list *VirtualList) (root, view *View, created *int) {
+	created = new(int)
+	list.NewRow = func() *View {
+		*created++
+		return &View{Handler: &listRow{}}
+	}
+	list.BindRow = func(row *View, index int) {
+		h := row.Handler.(*listRow)
+		h.index = index
+		h.binds++
+	}
+	view = &View{Width: 100, Height: 100, Overflow: OverflowScroll, Handler: list}
+	root = (&View{Width: 200, Height: 200, AlignItems: AlignItemStart}).AddChild(view)
+	root.Update()
+	root.Draw(nil)
+	return root, view, created
+}
+
+// rowFrames returns the frames of the instantiated rows by their index.
+func rowFrames(view *View) map[int]image.Rectangle {
+	frames := map[int]image.Rectangle{}
+	for _, c := range view.children {
+		if h, ok := c.item.Handler.(*listRow); ok {
+			frames[h.index] = c.item.frame
+		}
+	}
+	return frames
+}
+
+func TestVirtualList(t *testing.T) {
+	list := &VirtualList{RowCount: 1000, RowHeight: 20}
+	root, view, created := newTestList(list)
+
+	first, last := list.VisibleRows()
+	require.Equal(t, [2]int{0, 5}, [2]int{first, last})
+	require.Equal(t, 5, *created)
+	require.Len(t, view.children, 7)
+	require.Equal(t, image.Pt(100, 20000), view.scroll.contentSize)
+	require.Equal(t, image.Rect(0, 20, 100, 40), rowFrames(view)[1])
+
+	view.ScrollTo(0, 1010)
+	root.Update()
+	root.Draw(nil)
+	first, last = list.VisibleRows()
+	require.Equal(t, [2]int{50, 56}, [2]int{first, last})
+	require.Equal(t, 6, *created, "the rows are recycled")
+	require.Len(t, view.children, 8)
+	require.Equal(t, image.Pt(100, 20000), view.scroll.contentSize)
+	frames := rowFrames(view)
+	require.Len(t, frames, 6)
+	require.Equal(t, image.Rect(0, -10, 100, 10), frames[50])
+	require.Equal(t, image.Rect(0, 90, 100, 110), frames[55])
+
+	list.ScrollToRow(999)
+	root.Update()
+	root.Update()
+	root.Draw(nil)
+	require.Equal(t, image.Pt(0, 19900), view.ScrollOffset())
+	first, last = list.VisibleRows()
+	require.Equal(t, [2]int{995, 1000}, [2]int{first, last})
+	require.Equal(t, image.Rect(0, 80, 100, 100), rowFrames(view)[999])
+}
+
+func TestVirtualListOverscan(t *testing.T) {
+	list := &VirtualList{RowCount: 1000, RowHeight: 20, Overscan: 2}
+	root, view, _ := newTestList(list)
+
+	first, last := list.VisibleRows()
+	require.Equal(t, [2]int{0, 7}, [2]int{first, last})
+
+	view.ScrollTo(0, 200)
+	root.Update()
+	first, last = list.VisibleRows()
+	require.Equal(t, [2]int{8, 17}, [2]int{first, last})
+}
+
+func TestVirtualListMeasureRow(t *testing.T) {
+	list := &VirtualList{
+		RowCount: 100,
+		MeasureRow: func(index int) int {
+			if index%2 == 0 {
+				return 10
+			}
+			return 30
+		},
+	}
+	root, view, _ := newTestList(list)
+	require.Equal(t, image.Pt(100, 2000), view.scroll.contentSize)
+
+	view.ScrollTo(0, 45)
+	root.Update()
+	root.Draw(nil)
+	first, last := list.VisibleRows()
+	require.Equal(t, [2]int{2, 8}, [2]int{first, last})
+	frames := rowFrames(view)
+	require.Equal(t, image.Rect(0, -5, 100, 5), frames[2])
+	require.Equal(t, image.Rect(0, 5, 100, 35), frames[3])
+}
+
+func TestVirtualListRefresh(t *testing.T) {
+	list := &VirtualList{RowCount: 1000, RowHeight: 20}
+	root, view, _ := newTestList(list)
+
+	list.RowCount = 3
+	list.Refresh()
+	root.Draw(nil)
+	first, last := list.VisibleRows()
+	require.Equal(t, [2]int{0, 3}, [2]int{first, last})
+	require.Equal(t, image.Pt(100, 60), view.scroll.contentSize)
+	for _, c := range view.children {
+		if h, ok := c.item.Handler.(*listRow); ok {
+			require.Equal(t, 2, h.binds)
+		}
+	}
+}
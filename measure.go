@@ -0,0 +1,157 @@
+package furex
+
+import "strings"
+
+// FontMetrics measures text. It is used to measure the Text of views
+// whose handler doesn't implement Measurer.
+type FontMetrics interface {
+	// MeasureText returns the size of the text when it is drawn.
+	MeasureText(text string) (width, height int)
+}
+
+// DefaultFontMetrics is the FontMetrics used to measure the Text of views.
+// By default, it measures text drawn with the debug font of ebitenutil.
+// Set it to the metrics of the font you draw text with, or nil to disable
+// the measurement of text.
+var DefaultFontMetrics FontMetrics = FixedFontMetrics{GlyphWidth: 6, LineHeight: 16}
+
+// FixedFontMetrics measures text drawn with a font whose glyphs
+// have the same size, such as a bitmap font.
+type FixedFontMetrics struct {
+	GlyphWidth int
+	LineHeight int
+}
+
+// MeasureText returns the size of the text.
+func (m FixedFontMetrics) MeasureText(text string) (width, height int) {
+	lines := strings.Split(text, "\n")
+	for _, line := range lines {
+		if w := len([]rune(line)) * m.GlyphWidth; w > width {
+			width = w
+		}
+	}
+	return width, len(lines) * m.LineHeight
+}
+
+type textMeasurer struct {
+	text    string
+	metrics FontMetrics
+}
+
+func (t *textMeasurer) Measure(availableWidth, availableHeight int, mode MeasureMode) (int, int) {
+	return t.metrics.MeasureText(t.text)
+}
+
+// Baseline approximates the baseline of the text by the bottom of its first line.
+func (t *textMeasurer) Baseline(width, height int) int {
+	line, _, _ := strings.Cut(t.text, "\n")
+	_, h := t.metrics.MeasureText(line)
+	return h
+}
+
+// measurer returns the Measurer of the view, which is the handler if it
+// implements Measurer, or the measurer of the text for text-only views.
+func (v *View) measurer() Measurer {
+	if m, ok := v.Handler.(Measurer); ok {
+		return m
+	}
+	if v.Text != "" && DefaultFontMetrics != nil {
+		return &textMeasurer{text: v.Text, metrics: DefaultFontMetrics}
+	}
+	return nil
+}
+
+// measure sets the calculated size of a view without children
+// to the size of its content. The width and height are the
+// available space in the container.
+func (v *View) measure(width, height int) {
+	if len(v.children) > 0 || (v.isWidthFixed() && v.isHeightFixed()) {
+		return
+	}
+	m := v.measurer()
+	if m == nil {
+		return
+	}
+	paddingX := v.PaddingLeft + v.PaddingRight
+	paddingY := v.PaddingTop + v.PaddingBottom
+	if v.Width != 0 {
+		width = v.Width
+	}
+	if v.Height != 0 {
+		height = v.Height
+	}
+	mode := MeasureAtMost
+	if width == 0 && height == 0 {
+		mode = MeasureUndefined
+	}
+	key := measureKey{
+		width:    nonNegative(width - paddingX),
+		height:   nonNegative(height - paddingY),
+		mode:     mode,
+		paddingX: paddingX,
+		paddingY: paddingY,
+		text:     v.Text,
+	}
+	if !v.measured.valid || v.measured.key != key {
+		w, h := m.Measure(key.width, key.height, mode)
+		v.measured = measureCache{valid: true, key: key, width: w + paddingX, height: h + paddingY}
+	}
+	v.calculatedWidth = v.measured.width
+	v.calculatedHeight = v.measured.height
+}
+
+// measureCache holds the last measurement of a view. It is reused until
+// the inputs of the measurement change or the view is marked dirty.
+type measureCache struct {
+	valid         bool
+	key           measureKey
+	width, height int
+}
+
+type measureKey struct {
+	width, height      int
+	mode               MeasureMode
+	paddingX, paddingY int
+	text               string
+}
+
+// baseline returns the distance from the top of the view to its first
+// baseline when its size is width x height (§8.5). It is the baseline of
+// the handler, or of the first child that is aligned by baseline, or else
+// of the first child, or of the text. If there is none, the baseline is
+// synthesized from the bottom edge.
+func (v *View) baseline(width, height int) int {
+	paddingX := v.PaddingLeft + v.PaddingRight
+	paddingY := v.PaddingTop + v.PaddingBottom
+	if b, ok := v.Handler.(Baseliner); ok {
+		return v.PaddingTop + b.Baseline(nonNegative(width-paddingX), nonNegative(height-paddingY))
+	}
+	f := flexEmbed{View: v}
+	var first *child
+	for _, c := range v.orderedChildren() {
+		if c.absolute || c.item.Display == DisplayNone {
+			continue
+		}
+		if f.alignItem(c.item) == AlignItemBaseline {
+			first = c
+			break
+		}
+		if first == nil {
+			first = c
+		}
+	}
+	if first != nil {
+		return first.bounds.Min.Y + first.item.baseline(first.bounds.Dx(), first.bounds.Dy())
+	}
+	if b, ok := v.measurer().(Baseliner); ok {
+		return v.PaddingTop + b.Baseline(nonNegative(width-paddingX), nonNegative(height-paddingY))
+	}
+	return height
+}
+
+func nonNegative(v int) int {
+	if v < 0 {
+		return 0
+	}
+	return v
+}
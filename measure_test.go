@@ -0,0 +1,77 @@
+package furex
+
+import (
+	"image"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type mockMeasurer struct {
+	mockHandler
+	width, height int
+	available     image.Point
+	mode          MeasureMode
+}
+
+func (m *mockMeasurer) Measure(availableWidth, availableHeight int, mode MeasureMode) (int, int) {
+	m.available = image.Pt(availableWidth, availableHeight)
+	m.mode = mode
+	return m.width, m.height
+}
+
+func TestMeasurer(t *testing.T) {
+	flex := &View{
+		Width:      200,
+		Height:     100,
+		Direction:  Row,
+		AlignItems: AlignItemStart,
+	}
+
+	m := &mockMeasurer{width: 40, height: 20}
+	fixed := &mockMeasurer{width: 40, height: 20}
+	flex.AddChild(
+		&View{PaddingLeft: 5, PaddingRight: 5, PaddingTop: 5, PaddingBottom: 5, Handler: m},
+		&View{Width: 100, Handler: fixed},
+	)
+
+	flex.Update()
+	flex.Draw(nil)
+
+	assert.Equal(t, image.Rect(0, 0, 50, 30), m.Frame)
+	assert.Equal(t, image.Pt(190, 90), m.available)
+	assert.Equal(t, MeasureAtMost, m.mode)
+
+	// The available width of a view with a fixed width is its own width.
+	assert.Equal(t, image.Rect(50, 0, 150, 20), fixed.Frame)
+	assert.Equal(t, image.Pt(100, 100), fixed.available)
+}
+
+func TestMeasureText(t *testing.T) {
+	defer func(m FontMetrics) { DefaultFontMetrics = m }(DefaultFontMetrics)
+	DefaultFontMetrics = FixedFontMetrics{GlyphWidth: 8, LineHeight: 10}
+
+	root := &View{
+		Width:      200,
+		Height:     200,
+		Direction:  Column,
+		AlignItems: AlignItemStart,
+	}
+
+	mocks := [3]mockHandler{}
+	label := &View{Handler: &mocks[1]}
+	label.AddChild(&View{Text: "Hello", Handler: &mocks[0]})
+	root.AddChild(
+		label,
+		&View{Text: "Lorem\nipsum dolor", Handler: &mocks[2]},
+	)
+
+	root.Update()
+	root.Draw(nil)
+	root.Update()
+	root.Draw(nil)
+
+	assert.Equal(t, image.Rect(0, 0, 40, 10), mocks[0].Frame)
+	assert.Equal(t, image.Rect(0, 0, 40, 10), mocks[1].Frame)
+	assert.Equal(t, image.Rect(0, 10, 88, 30), mocks[2].Frame)
+}
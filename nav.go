@@ -0,0 +1,151 @@
+package furex
+
+import (
+	"image"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+)
+
+// NavDirection is the direction of the spatial navigation.
+type NavDirection int
+
+const (
+	NavUp NavDirection = iota
+	NavDown
+	NavLeft
+	NavRight
+)
+
+func (d NavDirection) String() string {
+	switch d {
+	case NavUp:
+		return "up"
+	case NavDown:
+		return "down"
+	case NavLeft:
+		return "left"
+	case NavRight:
+		return "right"
+	}
+	return "unknown"
+}
+
+// Navigate moves the focus from the focused view of the tree to the
+// nearest focusable view in the direction, and reports whether it moved.
+// The attributes nav-up, nav-down, nav-left and nav-right of the focused
+// view, e.g. nav-down="#next", override the view to move to.
+// When no view has the focus, the first view in the Tab order is focused.
+func (v *View) Navigate(dir NavDirection) bool {
+	root := v.root()
+	focused := root.Focused()
+	if focused == nil {
+		views := root.focusOrder()
+		if len(views) == 0 {
+			return false
+		}
+		views[0].Focus()
+		return true
+	}
+	if id, ok := focused.Attrs["nav-"+dir.String()]; ok {
+		if target, ok := root.GetByID(strings.TrimPrefix(id, "#")); ok && target.isFocusable() {
+			target.Focus()
+			return true
+		}
+	}
+
+	var best *View
+	var bestScore, bestOffset int
+	for _, c := range root.focusOrder() {
+		if c == focused {
+			continue
+		}
+		score, offset, ok := navScore(focused.frame, c.frame, dir)
+		if !ok {
+			continue
+		}
+		if best == nil || score < bestScore || (score == bestScore && offset < bestOffset) {
+			best, bestScore, bestOffset = c, score, offset
+		}
+	}
+	if best == nil {
+		return false
+	}
+	best.Focus()
+	return true
+}
+
+// navScore scores the candidate frame c for the navigation from the frame
+// from in the direction. The candidate must lie further in the direction.
+// The score is the distance along the direction plus twice the distance
+// across it, and ties are broken by the offset between their centers
+// across the direction.
+func navScore(from, c image.Rectangle, dir NavDirection) (score, offset int, ok bool) {
+	// Rotate the frames so that the direction is down.
+	switch dir {
+	case NavUp:
+		from, c = flipY(from), flipY(c)
+	case NavLeft:
+		from, c = flipY(transpose(from)), flipY(transpose(c))
+	case NavRight:
+		from, c = transpose(from), transpose(c)
+	}
+	if c.Min.Y <= from.Min.Y || c.Max.Y <= from.Max.Y {
+		return 0, 0, false
+	}
+	along := nonNegative(c.Min.Y - from.Max.Y)
+	across := nonNegative(c.Min.X-from.Max.X) + nonNegative(from.Min.X-c.Max.X)
+	offset = abs((c.Min.X + c.Max.X) - (from.Min.X + from.Max.X))
+	return along + 2*across, offset, true
+}
+
+func transpose(r image.Rectangle) image.Rectangle {
+	return image.Rect(r.Min.Y, r.Min.X, r.Max.Y, r.Max.X)
+}
+
+func flipY(r image.Rectangle) image.Rectangle {
+	return image.Rect(r.Min.X, -r.Max.Y, r.Max.X, -r.Min.Y)
+}
+
+var navKeys = map[ebiten.Key]NavDirection{
+	ebiten.KeyArrowUp:    NavUp,
+	ebiten.KeyArrowDown:  NavDown,
+	ebiten.KeyArrowLeft:  NavLeft,
+	ebiten.KeyArrowRight: NavRight,
+}
+
+var navGamepadButtons = map[ebiten.StandardGamepadButton]NavDirection{
+	ebiten.StandardGamepadButtonLeftTop:    NavUp,
+	ebiten.StandardGamepadButtonLeftBottom: NavDown,
+	ebiten.StandardGamepadButtonLeftLeft:   NavLeft,
+	ebiten.StandardGamepadButtonLeftRight:  NavRight,
+}
+
+// handleNavigation navigates with the arrow keys and the D-pad of
+// standard gamepads, and presses the focused button with the bottom
+// face button of the gamepads (A on Xbox controllers). The keys handled
+// by a KeyHandler are skipped.
+func (v *View) handleNavigation(handled map[ebiten.Key]bool) {
+	for k, dir := range navKeys {
+		if inpututil.IsKeyJustPressed(k) && !handled[k] {
+			v.Navigate(dir)
+		}
+	}
+	for _, id := range ebiten.AppendGamepadIDs(nil) {
+		if !ebiten.IsStandardGamepadLayoutAvailable(id) {
+			continue
+		}
+		for b, dir := range navGamepadButtons {
+			if inpututil.IsStandardGamepadButtonJustPressed(id, b) {
+				v.Navigate(dir)
+			}
+		}
+		if inpututil.IsStandardGamepadButtonJustPressed(id, ebiten.StandardGamepadButtonRightBottom) {
+			v.pressFocused()
+		}
+		if inpututil.IsStandardGamepadButtonJustReleased(id, ebiten.StandardGamepadButtonRightBottom) {
+			v.releaseFocused()
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package furex
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// newNavGrid returns a menu of 3x3 buttons of 20x20 with gaps of 10,
+// except for the center one, which is wider and shifted to the right.
+func newNavGrid() (root *View, buttons [3][3]*View) {
+	root = &View{Width: 100, Height: 100, Direction: Column, AlignItems: AlignItemStart}
+	for y := range buttons {
+		row := &View{Direction: Row, Height: 20, MarginBottom: 10, ColumnGap: 10}
+		for x := range buttons[y] {
+			buttons[y][x] = &View{Width: 20, Height: 20, Handler: &mockHandler{}}
+			row.AddChild(buttons[y][x])
+		}
+		root.AddChild(row)
+	}
+	buttons[1][1].Width = 25
+	root.Update()
+	return root, buttons
+}
+
+func TestNavigate(t *testing.T) {
+	root, buttons := newNavGrid()
+
+	require.True(t, root.Navigate(NavDown), "focuses the first view")
+	require.Equal(t, buttons[0][0], root.Focused())
+
+	for _, tt := range []struct {
+		dir  NavDirection
+		want *View
+	}{
+		{NavDown, buttons[1][0]},
+		{NavRight, buttons[1][1]},
+		{NavRight, buttons[1][2]},
+		{NavUp, buttons[0][2]},
+		{NavLeft, buttons[0][1]},
+		{NavDown, buttons[1][1]},
+		{NavDown, buttons[2][1]},
+		{NavLeft, buttons[2][0]},
+	} {
+		require.True(t, root.Navigate(tt.dir), tt.dir)
+		require.Equal(t, tt.want, root.Focused(), tt.dir)
+	}
+
+	require.False(t, root.Navigate(NavDown), "no view below")
+	require.False(t, root.Navigate(NavLeft), "no view to the left")
+	require.Equal(t, buttons[2][0], root.Focused())
+}
+
+func TestNavigateOverride(t *testing.T) {
+	root, buttons := newNavGrid()
+	buttons[2][2].ID = "last"
+	buttons[0][0].Attrs = map[string]string{"nav-down": "#last", "nav-right": "#missing"}
+
+	buttons[0][0].Focus()
+	require.True(t, root.Navigate(NavDown))
+	require.Equal(t, buttons[2][2], root.Focused())
+
+	buttons[0][0].Focus()
+	require.True(t, root.Navigate(NavRight), "falls back to the nearest view")
+	require.Equal(t, buttons[0][1], root.Focused())
+}
+
+func TestNavigateSkipsHidden(t *testing.T) {
+	root, buttons := newNavGrid()
+	buttons[1][0].SetHidden(true)
+	buttons[0][0].Focus()
+	require.True(t, root.Navigate(NavDown))
+	require.Equal(t, buttons[1][1], root.Focused())
+}
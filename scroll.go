@@ -0,0 +1,290 @@
+package furex
+
+import (
+	"image"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+const (
+	// scrollWheelStep is the distance scrolled by a notch of the mouse wheel.
+	scrollWheelStep = 40.
+	// scrollDragSlop is the distance a pointer moves before it starts dragging.
+	scrollDragSlop = 8
+	// scrollFriction is the rate at which a fling keeps its velocity per tick.
+	scrollFriction = 0.95
+	// scrollOverscrollFriction is the rate at which a fling keeps its
+	// velocity per tick beyond the edges.
+	scrollOverscrollFriction = 0.5
+	// scrollMinVelocity is the velocity below which a fling stops.
+	scrollMinVelocity = 0.1
+	// scrollResistance is the rate at which dragging beyond the edges
+	// moves the content.
+	scrollResistance = 0.5
+	// scrollBounce is the rate of the overscroll recovered per tick.
+	scrollBounce = 0.2
+)
+
+// scrollState is the scroll position of a view.
+type scrollState struct {
+	x, y        float64
+	vx, vy      float64 // the velocity of a fling, in pixels per tick
+	dragging    bool
+	bouncing    bool
+	contentSize image.Point
+}
+
+// ScrollOffset returns the distance the content of the view is scrolled by.
+func (v *View) ScrollOffset() image.Point {
+	return image.Pt(round(v.scroll.x), round(v.scroll.y))
+}
+
+// ScrollTo scrolls the content of the view to the offset (x, y),
+// which is clamped to the scrollable range once the view is laid out.
+// The views whose overflow is visible are not scrolled.
+func (v *View) ScrollTo(x, y int) {
+	v.scroll.vx, v.scroll.vy = 0, 0
+	v.scroll.bouncing = false
+	v.setScroll(float64(x), float64(y))
+}
+
+// maxScroll returns the maximum scroll offset of the view.
+func (v *View) maxScroll() (x, y float64) {
+	if v.Overflow == OverflowVisible {
+		return 0, 0
+	}
+	x = math.Max(0, float64(v.scroll.contentSize.X-v.frame.Dx()))
+	y = math.Max(0, float64(v.scroll.contentSize.Y-v.frame.Dy()))
+	return x, y
+}
+
+func (v *View) setScroll(x, y float64) {
+	if x == v.scroll.x && y == v.scroll.y {
+		return
+	}
+	v.scroll.x, v.scroll.y = x, y
+	// Scrolling doesn't change the size of the view,
+	// so the parent doesn't need to be laid out.
+	v.markDirty()
+}
+
+// updateScroll moves the content of the view by one tick
+// of a fling or a bounce, or clamps it to the scrollable range.
+func (v *View) updateScroll() {
+	s := &v.scroll
+	if s.dragging {
+		return
+	}
+	maxX, maxY := v.maxScroll()
+	x, y := s.x, s.y
+	if s.vx != 0 || s.vy != 0 {
+		x, y = x+s.vx, y+s.vy
+		friction := scrollFriction
+		if x < 0 || x > maxX || y < 0 || y > maxY {
+			friction = scrollOverscrollFriction
+			s.bouncing = true
+		}
+		s.vx, s.vy = s.vx*friction, s.vy*friction
+		if math.Hypot(s.vx, s.vy) < scrollMinVelocity {
+			s.vx, s.vy = 0, 0
+		}
+		v.setScroll(x, y)
+		return
+	}
+	cx, cy := clampScroll(x, maxX), clampScroll(y, maxY)
+	if s.bouncing {
+		x, y = x+(cx-x)*scrollBounce, y+(cy-y)*scrollBounce
+		if math.Abs(cx-x) < .5 && math.Abs(cy-y) < .5 {
+			x, y = cx, cy
+			s.bouncing = false
+		}
+		v.setScroll(x, y)
+		return
+	}
+	v.setScroll(cx, cy)
+}
+
+func clampScroll(v, max float64) float64 {
+	return math.Max(0, math.Min(v, max))
+}
+
+// rubberBand resists scrolling beyond the edges.
+func rubberBand(v, max float64) float64 {
+	switch {
+	case v < 0:
+		return v * scrollResistance
+	case v > max:
+		return max + (v-max)*scrollResistance
+	}
+	return v
+}
+
+// scrollDrag tracks a pointer that may drag a scroll view.
+// The pointer is the touch ID, or -1 for the mouse.
+type scrollDrag struct {
+	pointer              ebiten.TouchID
+	start, last          image.Point
+	views                []*View // the scroll views under the pointer, topmost first
+	view                 *View   // the view being dragged, once the drag began
+	startX, startY       float64
+	velocityX, velocityY float64
+}
+
+// scrollViewsAt returns the scroll views under (x, y), topmost first.
+// The view itself comes last, so that the root can be scrolled too.
+func (v *View) scrollViewsAt(x, y int) []*View {
+	var views []*View
+	layers := v.stackingOrder()
+	for i := len(layers) - 1; i >= 0; i-- {
+		item := layers[i].child.item
+		if item.Overflow == OverflowScroll && isInside(layers[i].frame(), x, y) {
+			views = append(views, item)
+		}
+	}
+	if v.Overflow == OverflowScroll && isInside(&v.frame, x, y) {
+		views = append(views, v)
+	}
+	return views
+}
+
+// startScrollDrag starts tracking the pointer pressed at (x, y).
+func (v *View) startScrollDrag(pointer ebiten.TouchID, x, y int) {
+	v.endScrollDrag(pointer, x, y)
+	d := &scrollDrag{pointer: pointer, start: image.Pt(x, y), last: image.Pt(x, y)}
+	d.views = v.scrollViewsAt(x, y)
+	for _, sv := range d.views {
+		// Touching a fling stops it.
+		sv.scroll.vx, sv.scroll.vy = 0, 0
+	}
+	if len(d.views) > 0 {
+		v.scrollDrags = append(v.scrollDrags, d)
+	}
+}
+
+// moveScrollDrag drags the scroll view by the pointer moved to (x, y).
+// The drag begins when the pointer moves far enough, along an axis
+// the view can be scrolled.
+func (ct *containerEmbed) moveScrollDrag(pointer ebiten.TouchID, x, y int) {
+	d := ct.scrollDrag(pointer)
+	if d == nil {
+		return
+	}
+	pos := image.Pt(x, y)
+	if d.view == nil {
+		delta := pos.Sub(d.start)
+		if math.Hypot(float64(delta.X), float64(delta.Y)) < scrollDragSlop {
+			return
+		}
+		horizontal := abs(delta.X) > abs(delta.Y)
+		for _, v := range d.views {
+			maxX, maxY := v.maxScroll()
+			if (horizontal && maxX > 0) || (!horizontal && maxY > 0) {
+				d.view = v
+				break
+			}
+		}
+		if d.view == nil {
+			return
+		}
+		d.start, d.last = pos, pos
+		d.startX, d.startY = d.view.scroll.x, d.view.scroll.y
+		d.view.scroll.dragging = true
+		d.view.scroll.bouncing = false
+		d.view.cancelPresses(pointer, x, y)
+	}
+
+	v := d.view
+	maxX, maxY := v.maxScroll()
+	delta := pos.Sub(d.start)
+	sx, sy := v.scroll.x, v.scroll.y
+	if maxX > 0 {
+		sx = rubberBand(d.startX-float64(delta.X), maxX)
+	}
+	if maxY > 0 {
+		sy = rubberBand(d.startY-float64(delta.Y), maxY)
+	}
+	step := pos.Sub(d.last)
+	d.velocityX = d.velocityX/2 - float64(step.X)/2
+	d.velocityY = d.velocityY/2 - float64(step.Y)/2
+	d.last = pos
+	v.setScroll(sx, sy)
+}
+
+// endScrollDrag ends the drag of the pointer released at (x, y),
+// flinging the view with the velocity of the pointer.
+func (ct *containerEmbed) endScrollDrag(pointer ebiten.TouchID, x, y int) {
+	d := ct.scrollDrag(pointer)
+	if d == nil {
+		return
+	}
+	if d.view != nil {
+		if image.Pt(x, y) != d.last {
+			ct.moveScrollDrag(pointer, x, y)
+		}
+		s := &d.view.scroll
+		s.dragging = false
+		maxX, maxY := d.view.maxScroll()
+		if maxX > 0 {
+			s.vx = d.velocityX
+		}
+		if maxY > 0 {
+			s.vy = d.velocityY
+		}
+		s.bouncing = s.x < 0 || s.x > maxX || s.y < 0 || s.y > maxY
+	}
+	for i := range ct.scrollDrags {
+		if ct.scrollDrags[i] == d {
+			ct.scrollDrags = append(ct.scrollDrags[:i], ct.scrollDrags[i+1:]...)
+			break
+		}
+	}
+}
+
+func (ct *containerEmbed) scrollDrag(pointer ebiten.TouchID) *scrollDrag {
+	for _, d := range ct.scrollDrags {
+		if d.pointer == pointer {
+			return d
+		}
+	}
+	return nil
+}
+
+// handleWheel scrolls the topmost scroll view under (x, y) that can be
+// scrolled in the direction of the wheel, and reports whether it did.
+func (root *View) handleWheel(x, y int, dx, dy float64) bool {
+	for _, v := range root.scrollViewsAt(x, y) {
+		if v.scroll.dragging {
+			continue
+		}
+		maxX, maxY := v.maxScroll()
+		sx := clampScroll(v.scroll.x-dx*scrollWheelStep, maxX)
+		sy := clampScroll(v.scroll.y-dy*scrollWheelStep, maxY)
+		if sx == v.scroll.x && sy == v.scroll.y {
+			continue
+		}
+		v.scroll.vx, v.scroll.vy = 0, 0
+		v.scroll.bouncing = false
+		v.setScroll(sx, sy)
+		return true
+	}
+	return false
+}
+
+// cancelPresses cancels the presses of the buttons in the view by the
+// pointer, because the pointer started dragging the view.
+func (v *View) cancelPresses(pointer ebiten.TouchID, x, y int) {
+	for _, c := range v.children {
+		if c.isButtonPressed {
+			c.release(nil, pointer, x, y, true)
+		}
+		c.item.cancelPresses(pointer, x, y)
+	}
+}
+
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
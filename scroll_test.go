@@ -0,0 +1,191 @@
+package furex
+
+import (
+	"image"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// newScrollPanel returns a root with a panel of 100x50 that scrolls
+// three items of 100x40 vertically.
+func newScrollPanel(mocks *[3]mockHandler) (root, panel *View) {
+	panel = &View{
+		Width:      100,
+		Height:     50,
+		Direction:  Column,
+		AlignItems: AlignItemStart,
+		Overflow:   OverflowScroll,
+	}
+	for i := range mocks {
+		panel.AddChild(&View{Width: 100, Height: 40, Handler: &mocks[i]})
+	}
+	root = (&View{Width: 200, Height: 200, AlignItems: AlignItemStart}).AddChild(panel)
+	root.Update()
+	root.Draw(nil)
+	return root, panel
+}
+
+func settleScroll(root *View) {
+	for i := 0; i < 200; i++ {
+		root.Update()
+	}
+	root.Draw(nil)
+}
+
+func TestScrollTo(t *testing.T) {
+	mocks := [3]mockHandler{}
+	root, panel := newScrollPanel(&mocks)
+	require.Equal(t, image.Pt(100, 120), panel.scroll.contentSize)
+
+	panel.ScrollTo(0, 30)
+	root.Draw(nil)
+	require.Equal(t, image.Pt(0, 30), panel.ScrollOffset())
+	require.Equal(t, image.Rect(0, -30, 100, 10), mocks[0].Frame)
+	require.Equal(t, image.Rect(0, 50, 100, 90), panel.children[2].item.frame)
+
+	// Hit testing follows the scrolled frames.
+	root.pressPointer(-1, 10, 20)
+	require.False(t, mocks[0].IsPressed)
+	require.True(t, mocks[1].IsPressed)
+	root.releasePointer(-1, 10, 20)
+
+	panel.ScrollTo(0, 500)
+	root.Update()
+	root.Draw(nil)
+	require.Equal(t, image.Pt(0, 70), panel.ScrollOffset())
+	require.Equal(t, image.Rect(0, 10, 100, 50), mocks[2].Frame)
+
+	panel.SetOverflow(OverflowVisible)
+	root.Update()
+	root.Draw(nil)
+	require.Equal(t, image.Pt(0, 0), panel.ScrollOffset())
+	require.Equal(t, image.Rect(0, 0, 100, 40), mocks[0].Frame)
+}
+
+func TestScrollWheel(t *testing.T) {
+	mocks := [3]mockHandler{}
+	root, panel := newScrollPanel(&mocks)
+
+	require.False(t, root.handleWheel(150, 10, 0, -1), "outside the panel")
+	require.False(t, root.handleWheel(10, 10, -1, 0), "not scrollable horizontally")
+	require.False(t, root.handleWheel(10, 10, 0, 1), "at the top")
+
+	require.True(t, root.handleWheel(10, 10, 0, -1))
+	require.Equal(t, image.Pt(0, 40), panel.ScrollOffset())
+	require.True(t, root.handleWheel(10, 10, 0, -1))
+	require.Equal(t, image.Pt(0, 70), panel.ScrollOffset())
+	require.False(t, root.handleWheel(10, 10, 0, -1), "at the bottom")
+}
+
+func TestScrollWheelNested(t *testing.T) {
+	inner := &View{Width: 100, Height: 50, Direction: Column, Overflow: OverflowScroll}
+	inner.AddChild(&View{Width: 100, Height: 60})
+	outer := &View{Width: 100, Height: 100, Direction: Column, Overflow: OverflowScroll}
+	outer.AddChild(inner, &View{Width: 100, Height: 100})
+	root := (&View{Width: 200, Height: 200, AlignItems: AlignItemStart}).AddChild(outer)
+	root.Update()
+	root.Draw(nil)
+
+	require.True(t, root.handleWheel(10, 10, 0, -1))
+	require.Equal(t, image.Pt(0, 10), inner.ScrollOffset())
+	require.Equal(t, image.Pt(0, 0), outer.ScrollOffset())
+
+	// The inner view can't scroll any further, so the outer one does.
+	require.True(t, root.handleWheel(10, 10, 0, -1))
+	require.Equal(t, image.Pt(0, 10), inner.ScrollOffset())
+	require.Equal(t, image.Pt(0, 40), outer.ScrollOffset())
+}
+
+func TestScrollRoot(t *testing.T) {
+	mocks := [3]mockHandler{}
+	root := &View{Width: 100, Height: 50, Direction: Column, Overflow: OverflowScroll}
+	for i := range mocks {
+		root.AddChild(&View{Width: 100, Height: 40, Handler: &mocks[i]})
+	}
+	root.Update()
+	root.Draw(nil)
+
+	require.True(t, root.handleWheel(10, 10, 0, -1))
+	require.Equal(t, image.Pt(0, 40), root.ScrollOffset())
+
+	root.pressPointer(-1, 10, 30)
+	root.moveScrollDrag(-1, 10, 10)
+	require.True(t, root.scroll.dragging)
+	root.moveScrollDrag(-1, 10, 0)
+	require.Equal(t, image.Pt(0, 50), root.ScrollOffset())
+	root.releasePointer(-1, 10, 0)
+
+	settleScroll(root)
+	require.Equal(t, image.Pt(0, 70), root.ScrollOffset())
+	require.Equal(t, image.Rect(0, 10, 100, 50), mocks[2].Frame)
+}
+
+func TestScrollDrag(t *testing.T) {
+	mocks := [3]mockHandler{}
+	root, panel := newScrollPanel(&mocks)
+
+	root.pressPointer(-1, 10, 30)
+	require.True(t, mocks[0].IsPressed)
+
+	// Moving within the slop doesn't drag.
+	root.moveScrollDrag(-1, 10, 25)
+	require.False(t, panel.scroll.dragging)
+	require.False(t, mocks[0].IsReleased)
+
+	// The drag cancels the press of the button.
+	root.moveScrollDrag(-1, 10, 20)
+	require.True(t, panel.scroll.dragging)
+	require.True(t, mocks[0].IsReleased)
+	require.True(t, mocks[0].IsCancel)
+
+	root.moveScrollDrag(-1, 10, 10)
+	require.Equal(t, image.Pt(0, 10), panel.ScrollOffset())
+
+	root.releasePointer(-1, 10, 10)
+	require.True(t, mocks[0].IsCancel)
+	require.False(t, panel.scroll.dragging)
+	require.Empty(t, root.scrollDrags)
+
+	// The content keeps moving, and stops at the bottom.
+	root.Update()
+	require.Greater(t, panel.ScrollOffset().Y, 10)
+	settleScroll(root)
+	require.Equal(t, image.Pt(0, 70), panel.ScrollOffset())
+	require.Equal(t, image.Rect(0, 10, 100, 50), mocks[2].Frame)
+	require.False(t, panel.scroll.bouncing)
+}
+
+func TestScrollDragOverscroll(t *testing.T) {
+	mocks := [3]mockHandler{}
+	root, panel := newScrollPanel(&mocks)
+
+	root.startScrollDrag(0, 10, 10)
+	root.moveScrollDrag(0, 10, 30)
+	root.moveScrollDrag(0, 10, 50)
+	// Dragging beyond the top is resisted.
+	require.Equal(t, image.Pt(0, -10), panel.ScrollOffset())
+	root.Draw(nil)
+	require.Equal(t, image.Rect(0, 10, 100, 50), mocks[0].Frame)
+
+	root.endScrollDrag(0, 10, 50)
+	require.True(t, panel.scroll.bouncing)
+
+	// The content bounces back to the top.
+	settleScroll(root)
+	require.Equal(t, image.Pt(0, 0), panel.ScrollOffset())
+	require.Equal(t, image.Rect(0, 0, 100, 40), mocks[0].Frame)
+	require.False(t, panel.scroll.bouncing)
+}
+
+func TestScrollDragAxis(t *testing.T) {
+	mocks := [3]mockHandler{}
+	root, panel := newScrollPanel(&mocks)
+
+	// The panel only scrolls vertically, so a horizontal move doesn't drag it.
+	root.startScrollDrag(-1, 10, 10)
+	root.moveScrollDrag(-1, 40, 12)
+	require.False(t, panel.scroll.dragging)
+	root.endScrollDrag(-1, 40, 12)
+	require.Equal(t, image.Pt(0, 0), panel.ScrollOffset())
+}
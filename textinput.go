@@ -0,0 +1,426 @@
+package furex
+
+import (
+	"image"
+	"image/color"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/yohamta/furex/v2/internal/graphic"
+)
+
+// caretBlinkTicks is the number of ticks the caret is shown, and then hidden.
+const caretBlinkTicks = 30
+
+// TextInput is a handler for a single line text field. It is focused by
+// a click, Tab or the navigation, and edits its value while it has the
+// focus: the characters typed, including the ones committed by an IME,
+// are inserted at the caret, and
+//
+//   - Left, Right, Home and End move the caret, extending the selection
+//     with Shift,
+//   - Backspace and Delete delete the selection or a character,
+//   - Ctrl+A (Cmd+A on macOS) selects all, and Ctrl+C, Ctrl+X and Ctrl+V
+//     copy, cut and paste within the field,
+//   - Enter submits the value.
+//
+// It is the handler of the HTML element <input>, with the attributes value,
+// placeholder, maxlength and type="password".
+type TextInput struct {
+	// Value is the text of the field.
+	Value string
+	// Placeholder is the text shown while the value is empty.
+	Placeholder string
+	// MaxLength is the maximum number of characters of the value.
+	// Zero means no limit.
+	MaxLength int
+	// Password masks the characters of the value with MaskChar.
+	Password bool
+	// MaskChar is the character shown for each character of a password.
+	// Zero means '*'.
+	MaskChar rune
+
+	// Color is the color of the text, the caret and the selection.
+	// Nil means white.
+	Color color.Color
+	// PlaceholderColor is the color of the placeholder. Nil means gray.
+	PlaceholderColor color.Color
+	// Metrics measures the text. Nil means DefaultFontMetrics.
+	Metrics FontMetrics
+	// DrawText draws the text at (x, y). Nil means the debug font of
+	// ebitenutil, which is drawn in white.
+	DrawText func(screen *ebiten.Image, text string, x, y int, clr color.Color)
+
+	// OnChange is called when the value is edited.
+	OnChange func(value string)
+	// OnSubmit is called when Enter is pressed.
+	OnSubmit func(value string)
+
+	view      *View
+	focused   bool
+	caret     int // the position of the caret, in characters
+	anchor    int // the other end of the selection, or the caret
+	clipboard string
+	blink     int
+	scrollX   int
+
+	// press is a press received before the view was known,
+	// which is applied by the next update.
+	press *image.Point
+}
+
+var (
+	_ Updater    = (*TextInput)(nil)
+	_ Drawer     = (*TextInput)(nil)
+	_ Measurer   = (*TextInput)(nil)
+	_ Focusable  = (*TextInput)(nil)
+	_ KeyHandler = (*TextInput)(nil)
+)
+
+// SetValue replaces the value, cut to the max length,
+// and moves the caret to its end.
+func (t *TextInput) SetValue(value string) {
+	if runes := []rune(value); t.MaxLength > 0 && len(runes) > t.MaxLength {
+		value = string(runes[:t.MaxLength])
+	}
+	t.Value = value
+	t.caret = len([]rune(value))
+	t.anchor = t.caret
+	t.changed(false)
+}
+
+// Selection returns the range [start, end) of the characters selected,
+// which is empty when nothing is selected.
+func (t *TextInput) Selection() (start, end int) {
+	n := len([]rune(t.Value))
+	start, end = clampInt(t.anchor, 0, n), clampInt(t.caret, 0, n)
+	if start > end {
+		start, end = end, start
+	}
+	return start, end
+}
+
+// Select selects the characters in [start, end), with the caret at end.
+func (t *TextInput) Select(start, end int) {
+	n := len([]rune(t.Value))
+	t.anchor, t.caret = clampInt(start, 0, n), clampInt(end, 0, n)
+	t.showCaret()
+}
+
+// Update inserts the characters typed while the field has the focus.
+func (t *TextInput) Update(v *View) {
+	t.view = v
+	if p := t.press; p != nil {
+		t.press = nil
+		t.pressAt(p.X, p.Y)
+	}
+	t.scrollToCaret(v)
+	if t.focused && !v.IsFocused() {
+		// The view was removed from the tree with the focus.
+		t.HandleBlur()
+	}
+	if !t.focused {
+		return
+	}
+	t.blink = (t.blink + 1) % (caretBlinkTicks * 2)
+	var chars []rune
+	for _, r := range ebiten.AppendInputChars(nil) {
+		if !unicode.IsControl(r) {
+			chars = append(chars, r)
+		}
+	}
+	if len(chars) > 0 {
+		t.insert(string(chars))
+	}
+}
+
+// HandleFocus shows the caret.
+func (t *TextInput) HandleFocus() {
+	t.focused = true
+	t.blink = 0
+}
+
+// HandleBlur hides the caret and clears the selection.
+func (t *TextInput) HandleBlur() {
+	t.focused = false
+	t.anchor = t.caret
+}
+
+// HandlePress focuses the field and moves the caret to the character
+// under (x, y).
+func (t *TextInput) HandlePress(x, y int, _ ebiten.TouchID) {
+	if t.view == nil {
+		t.press = &image.Point{x, y}
+		return
+	}
+	t.pressAt(x, y)
+}
+
+func (t *TextInput) pressAt(x, y int) {
+	t.view.Focus()
+	i := t.indexAt(x - t.view.frame.Min.X - t.view.PaddingLeft + t.scrollX)
+	t.Select(i, i)
+}
+
+// HandleRelease does nothing. The field is focused when it is pressed.
+func (t *TextInput) HandleRelease(x, y int, isCancel bool) {}
+
+// HandleKeyDown edits the value with the key.
+func (t *TextInput) HandleKeyDown(key ebiten.Key, mods KeyModifier, repeat bool) bool {
+	n := len([]rune(t.Value))
+	shortcut := mods.Has(KeyModifierControl) || mods.Has(KeyModifierMeta)
+	move := func(i int) {
+		t.caret = clampInt(i, 0, n)
+		if !mods.Has(KeyModifierShift) {
+			t.anchor = t.caret
+		}
+		t.showCaret()
+	}
+	start, end := t.Selection()
+	switch {
+	case key == ebiten.KeyArrowLeft:
+		if start != end && !mods.Has(KeyModifierShift) {
+			move(start)
+		} else {
+			move(t.caret - 1)
+		}
+	case key == ebiten.KeyArrowRight:
+		if start != end && !mods.Has(KeyModifierShift) {
+			move(end)
+		} else {
+			move(t.caret + 1)
+		}
+	case key == ebiten.KeyHome:
+		move(0)
+	case key == ebiten.KeyEnd:
+		move(n)
+	case key == ebiten.KeyBackspace:
+		if start == end {
+			start = nonNegative(start - 1)
+		}
+		t.delete(start, end)
+	case key == ebiten.KeyDelete:
+		if start == end && end < n {
+			end++
+		}
+		t.delete(start, end)
+	case key == ebiten.KeyEnter || key == ebiten.KeyNumpadEnter:
+		if t.OnSubmit != nil && !repeat {
+			t.OnSubmit(t.Value)
+		}
+	case key == ebiten.KeySpace:
+		// The space is typed as a character. Handling the key keeps it
+		// from pressing the field as a button.
+	case shortcut && key == ebiten.KeyA:
+		t.Select(0, n)
+	case shortcut && (key == ebiten.KeyC || key == ebiten.KeyX):
+		// Copying a password would reveal it.
+		if start == end || t.Password {
+			return true
+		}
+		t.clipboard = string([]rune(t.Value)[start:end])
+		if key == ebiten.KeyX {
+			t.delete(start, end)
+		}
+	case shortcut && key == ebiten.KeyV:
+		t.insert(t.clipboard)
+	default:
+		return false
+	}
+	return true
+}
+
+// HandleKeyUp does nothing. The keys are handled when they are pressed.
+func (t *TextInput) HandleKeyUp(key ebiten.Key, mods KeyModifier) bool {
+	return false
+}
+
+// insert replaces the selection with the text, cut to the max length.
+func (t *TextInput) insert(text string) {
+	value := []rune(t.Value)
+	start, end := t.Selection()
+	runes := []rune(text)
+	if t.MaxLength > 0 {
+		room := nonNegative(t.MaxLength - (len(value) - (end - start)))
+		if len(runes) > room {
+			runes = runes[:room]
+		}
+	}
+	if len(runes) == 0 && start == end {
+		return
+	}
+	value = append(value[:start:start], append(runes, value[end:]...)...)
+	t.Value = string(value)
+	t.caret = start + len(runes)
+	t.anchor = t.caret
+	t.changed(true)
+}
+
+// delete deletes the characters in [start, end).
+func (t *TextInput) delete(start, end int) {
+	if start == end {
+		return
+	}
+	value := []rune(t.Value)
+	t.Value = string(append(value[:start:start], value[end:]...))
+	t.caret, t.anchor = start, start
+	t.changed(true)
+}
+
+func (t *TextInput) changed(notify bool) {
+	t.showCaret()
+	if t.view != nil {
+		t.view.Layout()
+	}
+	if notify && t.OnChange != nil {
+		t.OnChange(t.Value)
+	}
+}
+
+// showCaret shows the caret after it moved or the value changed,
+// and scrolls the text to it.
+func (t *TextInput) showCaret() {
+	t.blink = 0
+	if t.view != nil {
+		t.scrollToCaret(t.view)
+	}
+}
+
+// scrollToCaret scrolls the text so that the caret stays in the frame
+// of the view, without scrolling past the end of the text.
+func (t *TextInput) scrollToCaret(v *View) {
+	width := v.frame.Dx() - v.PaddingLeft - v.PaddingRight
+	if width <= 0 {
+		return
+	}
+	caretX := t.offset(t.caret)
+	switch {
+	case caretX-t.scrollX > width-1:
+		t.scrollX = caretX - width + 1
+	case caretX < t.scrollX:
+		t.scrollX = caretX
+	}
+	if limit := nonNegative(t.offset(len([]rune(t.Value))) - width + 1); t.scrollX > limit {
+		t.scrollX = limit
+	}
+}
+
+// text returns the text shown for the value.
+func (t *TextInput) text() string {
+	if !t.Password {
+		return t.Value
+	}
+	mask := t.MaskChar
+	if mask == 0 {
+		mask = '*'
+	}
+	return strings.Repeat(string(mask), len([]rune(t.Value)))
+}
+
+func (t *TextInput) metrics() FontMetrics {
+	if t.Metrics != nil {
+		return t.Metrics
+	}
+	if DefaultFontMetrics != nil {
+		return DefaultFontMetrics
+	}
+	return FixedFontMetrics{GlyphWidth: 6, LineHeight: 16}
+}
+
+// offset returns the distance from the start of the text to the
+// character at the index.
+func (t *TextInput) offset(i int) int {
+	runes := []rune(t.text())
+	w, _ := t.metrics().MeasureText(string(runes[:clampInt(i, 0, len(runes))]))
+	return w
+}
+
+// indexAt returns the index of the character boundary nearest to
+// the distance x from the start of the text.
+func (t *TextInput) indexAt(x int) int {
+	n := len([]rune(t.Value))
+	prev := 0
+	for i := 1; i <= n; i++ {
+		w := t.offset(i)
+		if x < (prev+w)/2 {
+			return i - 1
+		}
+		prev = w
+	}
+	return n
+}
+
+// Measure returns the size of the value, or of the placeholder.
+func (t *TextInput) Measure(availableWidth, availableHeight int, mode MeasureMode) (int, int) {
+	text := t.text()
+	if text == "" {
+		text = t.Placeholder
+	}
+	return t.metrics().MeasureText(text)
+}
+
+// Draw draws the text, the selection and the caret, with the text
+// scrolled to the caret.
+func (t *TextInput) Draw(screen *ebiten.Image, frame image.Rectangle, v *View) {
+	inner := image.Rect(
+		frame.Min.X+v.PaddingLeft, frame.Min.Y+v.PaddingTop,
+		frame.Max.X-v.PaddingRight, frame.Max.Y-v.PaddingBottom)
+	if screen == nil || inner.Empty() {
+		return
+	}
+	dst := screen.SubImage(frame).(*ebiten.Image)
+	_, lineHeight := t.metrics().MeasureText("")
+
+	caretX := t.offset(t.caret)
+	x := inner.Min.X - t.scrollX
+
+	clr := t.Color
+	if clr == nil {
+		clr = color.White
+	}
+	if start, end := t.Selection(); t.focused && start != end {
+		selection := color.RGBAModel.Convert(clr).(color.RGBA)
+		selection.R, selection.G, selection.B, selection.A = selection.R/3, selection.G/3, selection.B/3, selection.A/3
+		graphic.FillRect(dst, &graphic.FillRectOpts{
+			Rect:  image.Rect(x+t.offset(start), inner.Min.Y, x+t.offset(end), inner.Min.Y+lineHeight),
+			Color: selection,
+		})
+	}
+
+	text, textClr := t.text(), clr
+	if text == "" {
+		text, textClr = t.Placeholder, t.PlaceholderColor
+		if textClr == nil {
+			textClr = color.Gray{0x80}
+		}
+	}
+	if t.DrawText != nil {
+		t.DrawText(dst, text, x, inner.Min.Y, textClr)
+	} else {
+		ebitenutil.DebugPrintAt(dst, text, x, inner.Min.Y)
+	}
+
+	if t.focused && t.blink < caretBlinkTicks {
+		graphic.FillRect(dst, &graphic.FillRectOpts{
+			Rect:  image.Rect(x+caretX, inner.Min.Y, x+caretX+1, inner.Min.Y+lineHeight),
+			Color: clr,
+		})
+	}
+}
+
+// setAttrs sets the fields of an <input> element from its attributes.
+func (t *TextInput) setAttrs(attrs map[string]string) {
+	if v, err := strconv.Atoi(strings.TrimSpace(attrs["maxlength"])); err == nil && v > 0 {
+		t.MaxLength = v
+	}
+	if v, ok := attrs["value"]; ok {
+		t.SetValue(v)
+	}
+	if v, ok := attrs["placeholder"]; ok {
+		t.Placeholder = v
+	}
+	t.Password = strings.EqualFold(attrs["type"], "password")
+}
@@ -0,0 +1,177 @@
+package furex
+
+import (
+	"image"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func newTextInput(value string) (*TextInput, *View) {
+	t := &TextInput{Metrics: FixedFontMetrics{GlyphWidth: 10, LineHeight: 20}}
+	v := &View{Width: 200, Height: 20, PaddingLeft: 5, Handler: t}
+	root := (&View{Width: 300, Height: 100}).AddChild(v)
+	root.Update()
+	t.SetValue(value)
+	return t, v
+}
+
+func TestTextInputEditing(t *testing.T) {
+	input, v := newTextInput("hello")
+	var changes []string
+	input.OnChange = func(value string) { changes = append(changes, value) }
+	v.Focus()
+
+	input.insert("!")
+	require.Equal(t, "hello!", input.Value)
+
+	input.HandleKeyDown(ebiten.KeyHome, 0, false)
+	input.HandleKeyDown(ebiten.KeyArrowRight, 0, false)
+	input.insert("é")
+	require.Equal(t, "héello!", input.Value)
+
+	input.HandleKeyDown(ebiten.KeyBackspace, 0, true)
+	input.HandleKeyDown(ebiten.KeyDelete, 0, false)
+	require.Equal(t, "hllo!", input.Value)
+
+	input.HandleKeyDown(ebiten.KeyEnd, 0, false)
+	input.HandleKeyDown(ebiten.KeyDelete, 0, false)
+	require.Equal(t, "hllo!", input.Value, "deleting at the end does nothing")
+
+	require.Equal(t, []string{"hello!", "héello!", "hello!", "hllo!"}, changes)
+}
+
+func TestTextInputSelection(t *testing.T) {
+	input, v := newTextInput("abcdef")
+	v.Focus()
+
+	input.HandleKeyDown(ebiten.KeyArrowLeft, KeyModifierShift, false)
+	input.HandleKeyDown(ebiten.KeyArrowLeft, KeyModifierShift, false)
+	start, end := input.Selection()
+	require.Equal(t, [2]int{4, 6}, [2]int{start, end})
+
+	input.HandleKeyDown(ebiten.KeyX, KeyModifierControl, false)
+	require.Equal(t, "abcd", input.Value)
+
+	input.HandleKeyDown(ebiten.KeyHome, 0, false)
+	input.HandleKeyDown(ebiten.KeyV, KeyModifierMeta, false)
+	require.Equal(t, "efabcd", input.Value)
+
+	input.HandleKeyDown(ebiten.KeyA, KeyModifierControl, false)
+	input.HandleKeyDown(ebiten.KeyC, KeyModifierControl, false)
+	input.insert("x")
+	require.Equal(t, "x", input.Value, "typing replaces the selection")
+	input.HandleKeyDown(ebiten.KeyV, KeyModifierControl, false)
+	require.Equal(t, "xefabcd", input.Value)
+
+	input.Select(1, 3)
+	input.HandleKeyDown(ebiten.KeyArrowRight, 0, false)
+	start, end = input.Selection()
+	require.Equal(t, [2]int{3, 3}, [2]int{start, end}, "moving collapses the selection")
+
+	input.Select(1, 3)
+	input.HandleBlur()
+	start, end = input.Selection()
+	require.Equal(t, start, end, "blurring clears the selection")
+}
+
+func TestTextInputMaxLengthAndPassword(t *testing.T) {
+	input, _ := newTextInput("abc")
+	input.MaxLength = 5
+	input.insert("defgh")
+	require.Equal(t, "abcde", input.Value)
+	input.insert("f")
+	require.Equal(t, "abcde", input.Value)
+
+	input.Select(0, 2)
+	input.insert("xyz")
+	require.Equal(t, "xycde", input.Value, "replacing the selection makes room")
+
+	input.SetValue("abcdefgh")
+	require.Equal(t, "abcde", input.Value, "set values are cut to the max length")
+
+	input.Password = true
+	require.Equal(t, "*****", input.text())
+	input.MaskChar = '•'
+	require.Equal(t, "•••••", input.text())
+	input.clipboard = ""
+	input.HandleKeyDown(ebiten.KeyA, KeyModifierControl, false)
+	input.HandleKeyDown(ebiten.KeyC, KeyModifierControl, false)
+	require.Empty(t, input.clipboard, "passwords are not copied")
+}
+
+func TestTextInputFocusAndKeys(t *testing.T) {
+	input, v := newTextInput("abc")
+	var submitted string
+	input.OnSubmit = func(value string) { submitted = value }
+	root := v.parent
+
+	require.False(t, root.dispatchKeyDown(ebiten.KeyArrowLeft, 0, false),
+		"the field doesn't handle keys without the focus")
+
+	input.HandlePress(v.frame.Min.X+5+14, 10, -1)
+	require.True(t, v.IsFocused(), "pressing the field focuses it")
+	start, end := input.Selection()
+	require.Equal(t, [2]int{1, 1}, [2]int{start, end}, "the caret moves to the nearest character")
+
+	require.True(t, root.dispatchKeyDown(ebiten.KeyEnter, 0, false))
+	require.Equal(t, "abc", submitted)
+	require.True(t, root.dispatchKeyDown(ebiten.KeySpace, 0, false))
+	require.False(t, root.dispatchKeyDown(ebiten.KeyTab, 0, false), "Tab moves the focus")
+	require.False(t, root.dispatchKeyDown(ebiten.KeyArrowDown, 0, false), "Up and Down navigate")
+
+	root.RemoveChild(v)
+	input.Update(v)
+	require.False(t, input.focused)
+}
+
+func TestTextInputPressBeforeUpdate(t *testing.T) {
+	input := &TextInput{Value: "abcdef", Metrics: FixedFontMetrics{GlyphWidth: 10, LineHeight: 20}}
+	v := &View{Width: 200, Height: 20, Handler: input}
+	root := (&View{Width: 300, Height: 100}).AddChild(v)
+	root.Draw(nil)
+
+	root.pressPointer(-1, 31, 5)
+	root.Update()
+	require.True(t, v.IsFocused(), "the press before the first update isn't lost")
+	start, end := input.Selection()
+	require.Equal(t, [2]int{3, 3}, [2]int{start, end})
+}
+
+func TestTextInputScroll(t *testing.T) {
+	input, v := newTextInput("")
+	v.Focus()
+	input.insert("abcdefghijklmnopqrstuvwxy")
+	require.Equal(t, 250-195+1, input.scrollX, "the text scrolls to the caret at its end")
+
+	root := v.parent
+	root.Draw(nil)
+	require.Equal(t, 56, input.scrollX, "drawing doesn't scroll")
+
+	input.HandleKeyDown(ebiten.KeyHome, 0, false)
+	require.Equal(t, 0, input.scrollX)
+
+	input.HandleKeyDown(ebiten.KeyEnd, 0, false)
+	input.SetValue("abc")
+	root.Update()
+	require.Equal(t, 0, input.scrollX, "the text isn't scrolled past its end")
+}
+
+func TestTextInputDrawWithoutScreen(t *testing.T) {
+	root := Parse(`<div style="width: 100; height: 20"><input value="x"></div>`, nil)
+	root.Update()
+	require.NotPanics(t, func() { root.Draw(nil) })
+}
+
+func TestTextInputMeasure(t *testing.T) {
+	input, v := newTextInput("")
+	input.Placeholder = "Name"
+	w, h := input.Measure(100, 100, MeasureAtMost)
+	require.Equal(t, image.Pt(40, 20), image.Pt(w, h))
+
+	input.SetValue("furex!")
+	w, _ = input.Measure(100, 100, MeasureAtMost)
+	require.Equal(t, 60, w)
+	require.True(t, v.isDirty, "changing the value lays out the view")
+}
@@ -14,27 +14,67 @@ import (
 // Handlers can be set to create custom component such as button or list.
 type View struct {
 	// TODO: Remove these fields in the future.
-	Left         int
-	Right        *int
-	Top          int
-	Bottom       *int
-	Width        int
-	WidthInPct   float64
-	Height       int
-	HeightInPct  float64
-	MarginLeft   int
-	MarginTop    int
-	MarginRight  int
-	MarginBottom int
-	Position     Position
-	Direction    Direction
-	Wrap         FlexWrap
-	Justify      Justify
-	AlignItems   AlignItem
-	AlignContent AlignContent
-	Grow         float64
-	Shrink       float64
-	Display      Display
+	// The insets of positioned views. Nil means the inset is unset, and an
+	// inset in percent is used only while the one in pixels is nil.
+	// Use Int to set an inset, e.g. Left: Int(0).
+	Left             *int
+	LeftInPct        float64
+	Right            *int
+	RightInPct       float64
+	Top              *int
+	TopInPct         float64
+	Bottom           *int
+	BottomInPct      float64
+	Width            int
+	WidthInPct       float64
+	Height           int
+	HeightInPct      float64
+	MinWidth         int
+	MinWidthInPct    float64
+	MaxWidth         int
+	MaxWidthInPct    float64
+	MinHeight        int
+	MinHeightInPct   float64
+	MaxHeight        int
+	MaxHeightInPct   float64
+	AspectRatio      float64
+	MarginLeft       int
+	MarginTop        int
+	MarginRight      int
+	MarginBottom     int
+	MarginLeftAuto   bool
+	MarginTopAuto    bool
+	MarginRightAuto  bool
+	MarginBottomAuto bool
+	PaddingLeft      int
+	PaddingTop       int
+	PaddingRight     int
+	PaddingBottom    int
+	RowGap           int
+	ColumnGap        int
+	Position         Position
+	Direction        Direction
+	Wrap             FlexWrap
+	Justify          Justify
+	AlignItems       AlignItem
+	AlignSelf        AlignSelf
+	AlignContent     AlignContent
+	Grow             float64
+	Shrink           float64
+	Basis            *int
+	BasisInPct       float64
+	Order            int
+	ZIndex           int
+	Overflow         Overflow
+	Display          Display
+
+	// GridTemplateColumns and GridTemplateRows are the tracks of the
+	// grid when the display is DisplayGrid.
+	GridTemplateColumns []GridTrack
+	GridTemplateRows    []GridTrack
+	// GridColumn and GridRow place the view in the grid of its parent.
+	GridColumn GridPlacement
+	GridRow    GridPlacement
 
 	ID      string
 	Raw     string
@@ -42,6 +82,11 @@ type View struct {
 	Text    string
 	Attrs   map[string]string
 	Hidden  bool
+	// TabIndex orders the view in the Tab navigation, like the tabindex
+	// attribute of HTML: the views with a positive index come first in
+	// ascending order, then the ones with 0 in tree order. Views with a
+	// negative index are skipped, but can still be focused with Focus.
+	TabIndex int
 
 	Handler Handler
 
@@ -50,13 +95,23 @@ type View struct {
 	lock      sync.Mutex
 	hasParent bool
 	parent    *View
+
+	// hasDirtyDescendant tells that some descendant needs to be laid out.
+	hasDirtyDescendant bool
+	measured           measureCache
+	// contentSize is the size of the content computed by the last layout
+	// of the view, which doesn't depend on the frame given by its parent.
+	contentSize image.Point
+
+	focus focusState
+
+	scroll scrollState
 }
 
 // Update updates the view
 func (v *View) Update() {
-	if v.isDirty {
-		v.startLayout()
-	}
+	v.layoutIfDirty()
+	v.updateScroll()
 	if !v.hasParent {
 		v.processHandler()
 	}
@@ -66,6 +121,9 @@ func (v *View) Update() {
 	}
 	if !v.hasParent {
 		v.processEvent()
+		handled := v.handleKeyEvents()
+		v.handleFocusKeys(handled)
+		v.handleNavigation(handled)
 	}
 }
 
@@ -80,22 +138,73 @@ func (v *View) processHandler() {
 	}
 }
 
+// startLayout lays out the children of the view, and then the children
+// that it resized.
 func (v *View) startLayout() {
 	v.lock.Lock()
-	defer v.lock.Unlock()
 	if !v.hasParent {
-		v.frame = image.Rect(v.Left, v.Top, v.Left+v.Width, v.Top+v.Height)
+		left, top := intValue(v.Left), intValue(v.Top)
+		v.frame = image.Rect(left, top, left+v.Width, top+v.Height)
 	}
 	v.flexEmbed.View = v
 
-	for _, child := range v.children {
-		if child.item.Position == PositionStatic {
-			child.item.startLayout()
+	if v.Display == DisplayGrid {
+		v.layoutGrid(v.frame.Dx(), v.frame.Dy(), &v.containerEmbed)
+	} else {
+		v.layout(v.frame.Dx(), v.frame.Dy(), &v.containerEmbed)
+	}
+	v.contentSize = image.Pt(v.calculatedWidth, v.calculatedHeight)
+	v.isDirty = false
+	v.invalidateLayers()
+	v.lock.Unlock()
+
+	for _, c := range v.children {
+		if c.item.isDirty {
+			c.item.startLayout()
 		}
 	}
+}
 
-	v.layout(v.frame.Dx(), v.frame.Dy(), &v.containerEmbed)
-	v.isDirty = false
+// layoutIfDirty lays out the dirty views in the tree of the view.
+// The dirty descendants are laid out before their ancestors, so that the
+// content size of the children is up to date when their parent lays them
+// out. The parent is only laid out again if the content size computed
+// by the layout of the view changed.
+func (v *View) layoutIfDirty() {
+	if v.hasDirtyDescendant {
+		v.hasDirtyDescendant = false
+		for _, c := range v.children {
+			c.item.layoutIfDirty()
+		}
+	}
+	if !v.isDirty {
+		return
+	}
+	old := v.contentSize
+	v.startLayout()
+	if v.hasParent &&
+		((old.X != v.contentSize.X && !v.isWidthFixed()) ||
+			(old.Y != v.contentSize.Y && !v.isHeightFixed())) {
+		v.parent.isDirty = true
+	}
+}
+
+// markDirty marks the view to be laid out, and its ancestors to look for it.
+func (v *View) markDirty() {
+	v.isDirty = true
+	for p := v.parent; p != nil && !p.hasDirtyDescendant; p = p.parent {
+		p.hasDirtyDescendant = true
+	}
+	v.invalidateLayers()
+}
+
+// invalidateLayers discards the stacking orders cached by the view and its
+// ancestors. Unlike the dirty flags, it always goes up to the root, since
+// an ancestor may have cached its order after the view was marked.
+func (v *View) invalidateLayers() {
+	for p := v; p != nil; p = p.parent {
+		p.layers = nil
+	}
 }
 
 // UpdateWithSize the view with modified height and width
@@ -103,24 +212,25 @@ func (v *View) UpdateWithSize(width, height int) {
 	if !v.hasParent && (v.Width != width || v.Height != height) {
 		v.Height = height
 		v.Width = width
-		v.isDirty = true
+		v.markDirty()
 	}
 	v.Update()
 }
 
-// Layout marks the view as dirty
+// Layout marks the view as dirty, and its parent that lays it out.
+// The ancestors above are laid out only if the content size of the
+// parent changes.
 func (v *View) Layout() {
-	v.isDirty = true
+	v.measured.valid = false
+	v.markDirty()
 	if v.hasParent {
-		v.parent.isDirty = true
+		v.parent.markDirty()
 	}
 }
 
 // Draw draws the view
 func (v *View) Draw(screen *ebiten.Image) {
-	if v.isDirty {
-		v.startLayout()
-	}
+	v.layoutIfDirty()
 	if !v.hasParent {
 		v.handleDrawRoot(screen, v.frame)
 	}
@@ -154,7 +264,7 @@ func (v *View) RemoveChild(cv *View) bool {
 	for i, child := range v.children {
 		if child.item == cv {
 			v.children = append(v.children[:i], v.children[i+1:]...)
-			v.isDirty = true
+			v.markDirty()
 			cv.hasParent = false
 			cv.parent = nil
 			return true
@@ -165,7 +275,7 @@ func (v *View) RemoveChild(cv *View) bool {
 
 // RemoveAll removes all children view
 func (v *View) RemoveAll() {
-	v.isDirty = true
+	v.markDirty()
 	for _, child := range v.children {
 		child.item.hasParent = false
 		child.item.parent = nil
@@ -180,7 +290,7 @@ func (v *View) PopChild() *View {
 	}
 	c := v.children[len(v.children)-1]
 	v.children = v.children[:len(v.children)-1]
-	v.isDirty = true
+	v.markDirty()
 	c.item.hasParent = false
 	c.item.parent = nil
 	return c.item
@@ -189,9 +299,10 @@ func (v *View) PopChild() *View {
 func (v *View) addChild(cv *View) *View {
 	child := &child{item: cv, handledTouchID: -1}
 	v.children = append(v.children, child)
-	v.isDirty = true
 	cv.hasParent = true
 	cv.parent = v
+	v.markDirty()
+	cv.markDirty()
 	return v
 }
 
@@ -206,6 +317,57 @@ func (v *View) width() int {
 	return v.Width
 }
 
+// clampWidth clamps the width w by the min and max width of the view.
+// Percentages are resolved against the width of the containing block.
+func (v *View) clampWidth(w float64, containerWidth int) float64 {
+	return clampSize(w,
+		resolveLength(v.MinWidth, v.MinWidthInPct, containerWidth),
+		resolveLength(v.MaxWidth, v.MaxWidthInPct, containerWidth))
+}
+
+// clampHeight clamps the height h by the min and max height of the view.
+// Percentages are resolved against the height of the containing block.
+func (v *View) clampHeight(h float64, containerHeight int) float64 {
+	return clampSize(h,
+		resolveLength(v.MinHeight, v.MinHeightInPct, containerHeight),
+		resolveLength(v.MaxHeight, v.MaxHeightInPct, containerHeight))
+}
+
+// resolveLength returns the length in pixels, or zero if it is not set.
+func resolveLength(px int, pct float64, base int) float64 {
+	if px != 0 {
+		return float64(px)
+	}
+	return float64(base) * pct / 100
+}
+
+// clampSize clamps size between min and max. A zero max means no limit.
+// The min wins when it is greater than the max, as in CSS.
+func clampSize(size, min, max float64) float64 {
+	if max > 0 && size > max {
+		size = max
+	}
+	if size < min {
+		size = min
+	}
+	return size
+}
+
+// margins returns the left, top, right and bottom margins.
+// Auto margins are returned as zero.
+func (v *View) margins() (left, top, right, bottom float64) {
+	side := func(m int, auto bool) float64 {
+		if auto {
+			return 0
+		}
+		return float64(m)
+	}
+	return side(v.MarginLeft, v.MarginLeftAuto),
+		side(v.MarginTop, v.MarginTopAuto),
+		side(v.MarginRight, v.MarginRightAuto),
+		side(v.MarginBottom, v.MarginBottomAuto)
+}
+
 func (v *View) isHeightFixed() bool {
 	return v.Height != 0 || v.HeightInPct != 0
 }
@@ -217,6 +379,37 @@ func (v *View) height() int {
 	return v.Height
 }
 
+// relativeOffset returns the offset of a relatively positioned view.
+// Left wins over Right and Top wins over Bottom. Percentages are resolved
+// against the size of the content box of the container.
+func (v *View) relativeOffset(containerWidth, containerHeight int) image.Point {
+	var x, y float64
+	if left, ok := resolveInset(v.Left, v.LeftInPct, containerWidth); ok {
+		x = left
+	} else if right, ok := resolveInset(v.Right, v.RightInPct, containerWidth); ok {
+		x = -right
+	}
+	if top, ok := resolveInset(v.Top, v.TopInPct, containerHeight); ok {
+		y = top
+	} else if bottom, ok := resolveInset(v.Bottom, v.BottomInPct, containerHeight); ok {
+		y = -bottom
+	}
+	return image.Pt(round(x), round(y))
+}
+
+// resolveInset returns the inset in pixels, and false if it is not set.
+// An inset in pixels wins over one in percent, which is resolved against
+// the size of the containing block.
+func resolveInset(px *int, pct float64, base int) (float64, bool) {
+	switch {
+	case px != nil:
+		return float64(*px), true
+	case pct != 0:
+		return float64(base) * pct / 100, true
+	}
+	return 0, false
+}
+
 func (v *View) getChildren() []*View {
 	if v == nil || v.children == nil {
 		return nil
@@ -254,25 +447,29 @@ func (v *View) MustGetByID(id string) *View {
 
 // SetLeft sets the left position of the view.
 func (v *View) SetLeft(left int) {
-	v.Left = left
+	v.Left = Int(left)
+	v.LeftInPct = 0
 	v.Layout()
 }
 
 // SetRight sets the right position of the view.
 func (v *View) SetRight(right int) {
 	v.Right = Int(right)
+	v.RightInPct = 0
 	v.Layout()
 }
 
 // SetTop sets the top position of the view.
 func (v *View) SetTop(top int) {
-	v.Top = top
+	v.Top = Int(top)
+	v.TopInPct = 0
 	v.Layout()
 }
 
 // SetBottom sets the bottom position of the view.
 func (v *View) SetBottom(bottom int) {
 	v.Bottom = Int(bottom)
+	v.BottomInPct = 0
 	v.Layout()
 }
 
@@ -288,6 +485,38 @@ func (v *View) SetHeight(height int) {
 	v.Layout()
 }
 
+// SetMinWidth sets the minimum width of the view.
+func (v *View) SetMinWidth(minWidth int) {
+	v.MinWidth = minWidth
+	v.Layout()
+}
+
+// SetMaxWidth sets the maximum width of the view.
+func (v *View) SetMaxWidth(maxWidth int) {
+	v.MaxWidth = maxWidth
+	v.Layout()
+}
+
+// SetMinHeight sets the minimum height of the view.
+func (v *View) SetMinHeight(minHeight int) {
+	v.MinHeight = minHeight
+	v.Layout()
+}
+
+// SetMaxHeight sets the maximum height of the view.
+func (v *View) SetMaxHeight(maxHeight int) {
+	v.MaxHeight = maxHeight
+	v.Layout()
+}
+
+// SetAspectRatio sets the preferred width to height ratio of the view.
+// When only one of the width and height is known, the other one is
+// derived from the ratio. Zero disables the ratio.
+func (v *View) SetAspectRatio(aspectRatio float64) {
+	v.AspectRatio = aspectRatio
+	v.Layout()
+}
+
 // SetMarginLeft sets the left margin of the view.
 func (v *View) SetMarginLeft(marginLeft int) {
 	v.MarginLeft = marginLeft
@@ -312,6 +541,83 @@ func (v *View) SetMarginBottom(marginBottom int) {
 	v.Layout()
 }
 
+// SetMarginLeftAuto sets whether the left margin of the view is auto.
+// Auto margins absorb the free space of the flex line.
+func (v *View) SetMarginLeftAuto(auto bool) {
+	v.MarginLeftAuto = auto
+	v.Layout()
+}
+
+// SetMarginTopAuto sets whether the top margin of the view is auto.
+func (v *View) SetMarginTopAuto(auto bool) {
+	v.MarginTopAuto = auto
+	v.Layout()
+}
+
+// SetMarginRightAuto sets whether the right margin of the view is auto.
+func (v *View) SetMarginRightAuto(auto bool) {
+	v.MarginRightAuto = auto
+	v.Layout()
+}
+
+// SetMarginBottomAuto sets whether the bottom margin of the view is auto.
+func (v *View) SetMarginBottomAuto(auto bool) {
+	v.MarginBottomAuto = auto
+	v.Layout()
+}
+
+// SetPaddingLeft sets the left padding of the view.
+func (v *View) SetPaddingLeft(paddingLeft int) {
+	v.PaddingLeft = paddingLeft
+	v.Layout()
+}
+
+// SetPaddingTop sets the top padding of the view.
+func (v *View) SetPaddingTop(paddingTop int) {
+	v.PaddingTop = paddingTop
+	v.Layout()
+}
+
+// SetPaddingRight sets the right padding of the view.
+func (v *View) SetPaddingRight(paddingRight int) {
+	v.PaddingRight = paddingRight
+	v.Layout()
+}
+
+// SetPaddingBottom sets the bottom padding of the view.
+func (v *View) SetPaddingBottom(paddingBottom int) {
+	v.PaddingBottom = paddingBottom
+	v.Layout()
+}
+
+// SetPadding sets the padding of all four sides of the view.
+func (v *View) SetPadding(top, right, bottom, left int) {
+	v.PaddingTop = top
+	v.PaddingRight = right
+	v.PaddingBottom = bottom
+	v.PaddingLeft = left
+	v.Layout()
+}
+
+// SetRowGap sets the gap between rows of the view.
+func (v *View) SetRowGap(rowGap int) {
+	v.RowGap = rowGap
+	v.Layout()
+}
+
+// SetColumnGap sets the gap between columns of the view.
+func (v *View) SetColumnGap(columnGap int) {
+	v.ColumnGap = columnGap
+	v.Layout()
+}
+
+// SetGap sets both the row gap and the column gap of the view.
+func (v *View) SetGap(rowGap, columnGap int) {
+	v.RowGap = rowGap
+	v.ColumnGap = columnGap
+	v.Layout()
+}
+
 // SetPosition sets the position of the view.
 func (v *View) SetPosition(position Position) {
 	v.Position = position
@@ -342,6 +648,12 @@ func (v *View) SetAlignItems(alignItems AlignItem) {
 	v.Layout()
 }
 
+// SetAlignSelf sets the align self property of the view.
+func (v *View) SetAlignSelf(alignSelf AlignSelf) {
+	v.AlignSelf = alignSelf
+	v.Layout()
+}
+
 // SetAlignContent sets the align content property of the view.
 func (v *View) SetAlignContent(alignContent AlignContent) {
 	v.AlignContent = alignContent
@@ -360,12 +672,63 @@ func (v *View) SetShrink(shrink float64) {
 	v.Layout()
 }
 
+// SetBasis sets the flex basis of the view.
+func (v *View) SetBasis(basis int) {
+	v.Basis = Int(basis)
+	v.BasisInPct = 0
+	v.Layout()
+}
+
+// SetOrder sets the order in which the view is laid out,
+// drawn and hit-tested among its siblings.
+func (v *View) SetOrder(order int) {
+	v.Order = order
+	v.Layout()
+}
+
+// SetZIndex sets the z-index of the view. Views with a greater z-index
+// are drawn above and receive pointer events before the others.
+func (v *View) SetZIndex(zIndex int) {
+	v.ZIndex = zIndex
+	v.Layout()
+}
+
+// SetOverflow sets the overflow property of the view.
+func (v *View) SetOverflow(overflow Overflow) {
+	v.Overflow = overflow
+	v.Layout()
+}
+
 // SetDisplay sets the display property of the view.
 func (v *View) SetDisplay(display Display) {
 	v.Display = display
 	v.Layout()
 }
 
+// SetGridTemplateColumns sets the columns of the grid of the view.
+func (v *View) SetGridTemplateColumns(columns []GridTrack) {
+	v.GridTemplateColumns = columns
+	v.Layout()
+}
+
+// SetGridTemplateRows sets the rows of the grid of the view.
+func (v *View) SetGridTemplateRows(rows []GridTrack) {
+	v.GridTemplateRows = rows
+	v.Layout()
+}
+
+// SetGridColumn sets the placement of the view in the columns of the grid.
+func (v *View) SetGridColumn(column GridPlacement) {
+	v.GridColumn = column
+	v.Layout()
+}
+
+// SetGridRow sets the placement of the view in the rows of the grid.
+func (v *View) SetGridRow(row GridPlacement) {
+	v.GridRow = row
+	v.Layout()
+}
+
 // SetHidden sets the hidden property of the view.
 func (v *View) SetHidden(hidden bool) {
 	v.Hidden = hidden
@@ -374,27 +737,61 @@ func (v *View) SetHidden(hidden bool) {
 
 func (v *View) Config() ViewConfig {
 	cfg := ViewConfig{
-		TagName:      v.TagName,
-		ID:           v.ID,
-		Left:         v.Left,
-		Right:        v.Right,
-		Top:          v.Top,
-		Bottom:       v.Bottom,
-		Width:        v.Width,
-		Height:       v.Height,
-		MarginLeft:   v.MarginLeft,
-		MarginTop:    v.MarginTop,
-		MarginRight:  v.MarginRight,
-		MarginBottom: v.MarginBottom,
-		Position:     v.Position,
-		Direction:    v.Direction,
-		Wrap:         v.Wrap,
-		Justify:      v.Justify,
-		AlignItems:   v.AlignItems,
-		AlignContent: v.AlignContent,
-		Grow:         v.Grow,
-		Shrink:       v.Shrink,
-		children:     []ViewConfig{},
+		TagName:          v.TagName,
+		ID:               v.ID,
+		Left:             v.Left,
+		LeftInPct:        v.LeftInPct,
+		Right:            v.Right,
+		RightInPct:       v.RightInPct,
+		Top:              v.Top,
+		TopInPct:         v.TopInPct,
+		Bottom:           v.Bottom,
+		BottomInPct:      v.BottomInPct,
+		Width:            v.Width,
+		Height:           v.Height,
+		MinWidth:         v.MinWidth,
+		MinWidthInPct:    v.MinWidthInPct,
+		MaxWidth:         v.MaxWidth,
+		MaxWidthInPct:    v.MaxWidthInPct,
+		MinHeight:        v.MinHeight,
+		MinHeightInPct:   v.MinHeightInPct,
+		MaxHeight:        v.MaxHeight,
+		MaxHeightInPct:   v.MaxHeightInPct,
+		AspectRatio:      v.AspectRatio,
+		MarginLeft:       v.MarginLeft,
+		MarginTop:        v.MarginTop,
+		MarginRight:      v.MarginRight,
+		MarginBottom:     v.MarginBottom,
+		MarginLeftAuto:   v.MarginLeftAuto,
+		MarginTopAuto:    v.MarginTopAuto,
+		MarginRightAuto:  v.MarginRightAuto,
+		MarginBottomAuto: v.MarginBottomAuto,
+		PaddingLeft:      v.PaddingLeft,
+		PaddingTop:       v.PaddingTop,
+		PaddingRight:     v.PaddingRight,
+		PaddingBottom:    v.PaddingBottom,
+		RowGap:           v.RowGap,
+		ColumnGap:        v.ColumnGap,
+		Position:         v.Position,
+		Direction:        v.Direction,
+		Wrap:             v.Wrap,
+		Justify:          v.Justify,
+		AlignItems:       v.AlignItems,
+		AlignSelf:        v.AlignSelf,
+		AlignContent:     v.AlignContent,
+		Grow:             v.Grow,
+		Shrink:           v.Shrink,
+		Basis:            v.Basis,
+		BasisInPct:       v.BasisInPct,
+		Order:            v.Order,
+		ZIndex:           v.ZIndex,
+		Overflow:         v.Overflow,
+
+		GridTemplateColumns: v.GridTemplateColumns,
+		GridTemplateRows:    v.GridTemplateRows,
+		GridColumn:          v.GridColumn,
+		GridRow:             v.GridRow,
+		children:            []ViewConfig{},
 	}
 	for _, child := range v.getChildren() {
 		cfg.children = append(cfg.children, child.Config())
@@ -414,27 +811,62 @@ func (v *View) handleDrawRoot(screen *ebiten.Image, b image.Rectangle) {
 
 // This is for debugging and testing.
 type ViewConfig struct {
-	TagName      string
-	ID           string
-	Left         int
-	Right        *int
-	Top          int
-	Bottom       *int
-	Width        int
-	Height       int
-	MarginLeft   int
-	MarginTop    int
-	MarginRight  int
-	MarginBottom int
-	Position     Position
-	Direction    Direction
-	Wrap         FlexWrap
-	Justify      Justify
-	AlignItems   AlignItem
-	AlignContent AlignContent
-	Grow         float64
-	Shrink       float64
-	children     []ViewConfig
+	TagName          string
+	ID               string
+	Left             *int
+	LeftInPct        float64
+	Right            *int
+	RightInPct       float64
+	Top              *int
+	TopInPct         float64
+	Bottom           *int
+	BottomInPct      float64
+	Width            int
+	Height           int
+	MinWidth         int
+	MinWidthInPct    float64
+	MaxWidth         int
+	MaxWidthInPct    float64
+	MinHeight        int
+	MinHeightInPct   float64
+	MaxHeight        int
+	MaxHeightInPct   float64
+	AspectRatio      float64
+	MarginLeft       int
+	MarginTop        int
+	MarginRight      int
+	MarginBottom     int
+	MarginLeftAuto   bool
+	MarginTopAuto    bool
+	MarginRightAuto  bool
+	MarginBottomAuto bool
+	PaddingLeft      int
+	PaddingTop       int
+	PaddingRight     int
+	PaddingBottom    int
+	RowGap           int
+	ColumnGap        int
+	Position         Position
+	Direction        Direction
+	Wrap             FlexWrap
+	Justify          Justify
+	AlignItems       AlignItem
+	AlignSelf        AlignSelf
+	AlignContent     AlignContent
+	Grow             float64
+	Shrink           float64
+	Basis            *int
+	BasisInPct       float64
+	Order            int
+	ZIndex           int
+	Overflow         Overflow
+
+	GridTemplateColumns []GridTrack
+	GridTemplateRows    []GridTrack
+	GridColumn          GridPlacement
+	GridRow             GridPlacement
+
+	children []ViewConfig
 }
 
 func (cfg ViewConfig) Tree() string {
@@ -450,8 +882,8 @@ func (cfg ViewConfig) tree(indent string) string {
 	}
 	sb.WriteString("style=\"")
 	sb.WriteString(
-		fmt.Sprintf("left: %d, right: %d, top: %d, bottom: %d, width: %d, height: %d, marginLeft: %d, marginTop: %d, marginRight: %d, marginBottom: %d, position: %s, direction: %s, wrap: %s, justify: %s, alignItems: %s, alignContent: %s, grow: %f, shrink: %f",
-			cfg.Left, *cfg.Right, cfg.Top, *cfg.Bottom, cfg.Width, cfg.Height, cfg.MarginLeft, cfg.MarginTop, cfg.MarginRight, cfg.MarginBottom, cfg.Position, cfg.Direction, cfg.Wrap, cfg.Justify, cfg.AlignItems, cfg.AlignContent, cfg.Grow, cfg.Shrink))
+		fmt.Sprintf("left: %s, right: %s, top: %s, bottom: %s, width: %d, height: %d, marginLeft: %d, marginTop: %d, marginRight: %d, marginBottom: %d, position: %s, direction: %s, wrap: %s, justify: %s, alignItems: %s, alignContent: %s, grow: %f, shrink: %f",
+			formatInset(cfg.Left), formatInset(cfg.Right), formatInset(cfg.Top), formatInset(cfg.Bottom), cfg.Width, cfg.Height, cfg.MarginLeft, cfg.MarginTop, cfg.MarginRight, cfg.MarginBottom, cfg.Position, cfg.Direction, cfg.Wrap, cfg.Justify, cfg.AlignItems, cfg.AlignContent, cfg.Grow, cfg.Shrink))
 	sb.WriteString("\">\n")
 	for _, child := range cfg.children {
 		sb.WriteString(child.tree(indent + "  "))
@@ -461,3 +893,11 @@ func (cfg ViewConfig) tree(indent string) string {
 	sb.WriteString("\n")
 	return sb.String()
 }
+
+// formatInset formats an inset in pixels, or "auto" if it is unset.
+func formatInset(inset *int) string {
+	if inset == nil {
+		return "auto"
+	}
+	return fmt.Sprintf("%d", *inset)
+}
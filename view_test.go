@@ -147,3 +147,127 @@ func TestUpdateOnlyOnce(t *testing.T) {
 	require.True(t, rootHandler.Times == 1)
 	require.True(t, nestedHandler.Times == 1)
 }
+
+func TestIncrementalLayout(t *testing.T) {
+	newTree := func(text string) (root, label *View, frames func() []image.Rectangle) {
+		label = &View{Text: text}
+		panel := (&View{Direction: Column, PaddingLeft: 2}).AddChild(label)
+		nested := (&View{Direction: Column}).AddChild(&View{Width: 10, Height: 10})
+		other := (&View{Width: 50, Height: 20}).AddChild(nested)
+		root = (&View{Width: 300, Height: 100, AlignItems: AlignItemStart}).AddChild(panel, other)
+		frames = func() []image.Rectangle {
+			return []image.Rectangle{panel.frame, label.frame, other.frame, nested.frame}
+		}
+		return root, label, frames
+	}
+
+	root, label, frames := newTree("ab")
+	root.Update()
+	root.Draw(nil)
+	require.Equal(t, image.Rect(0, 0, 14, 16), frames()[0])
+
+	// The content size of the label changes the size of the panel,
+	// which moves the other view and its descendants.
+	label.Text = "abcdef"
+	label.Layout()
+	root.Update()
+	root.Draw(nil)
+	fresh, _, want := newTree("abcdef")
+	fresh.Update()
+	fresh.Draw(nil)
+	require.Equal(t, want(), frames())
+	require.Equal(t, image.Rect(38, 0, 88, 20), frames()[2])
+	require.Equal(t, image.Rect(38, 0, 48, 20), frames()[3])
+
+	// A view whose size doesn't depend on its content doesn't lay out
+	// its ancestors again.
+	require.False(t, root.isDirty)
+	other := root.children[1].item
+	other.children[0].item.SetWidth(30)
+	require.False(t, root.isDirty)
+	require.True(t, root.hasDirtyDescendant)
+	root.Update()
+	require.False(t, root.hasDirtyDescendant)
+	require.Equal(t, image.Rect(38, 0, 88, 20), other.frame)
+}
+
+func TestIncrementalLayoutShrink(t *testing.T) {
+	newTree := func(width int) (root, label *View, frames func() []image.Rectangle) {
+		label = &View{Width: width, Height: 10}
+		mid := (&View{Direction: Column}).AddChild(label)
+		outer := (&View{PaddingLeft: 5}).AddChild(mid)
+		root = (&View{Width: 300, Height: 100, Direction: Column, AlignItems: AlignItemStart}).AddChild(outer)
+		frames = func() []image.Rectangle {
+			return []image.Rectangle{outer.frame, mid.frame, label.frame}
+		}
+		return root, label, frames
+	}
+
+	root, label, frames := newTree(60)
+	root.Update()
+	root.Draw(nil)
+	require.Equal(t, image.Rect(0, 0, 65, 10), frames()[0])
+
+	// Shrinking the label shrinks its auto-sized ancestors.
+	label.SetWidth(6)
+	root.Update()
+	root.Draw(nil)
+	fresh, _, want := newTree(6)
+	fresh.Update()
+	fresh.Draw(nil)
+	require.Equal(t, want(), frames())
+	require.Equal(t, image.Rect(0, 0, 11, 10), frames()[0])
+	require.Equal(t, image.Rect(5, 0, 11, 10), frames()[1])
+}
+
+// newBenchmarkTree returns a HUD of rows of panels with labels in them,
+// and the labels.
+func newBenchmarkTree(rows, panels, labels int) (root *View, leaves []*View) {
+	root = &View{Width: 1920, Height: 1080, Direction: Column}
+	for r := 0; r < rows; r++ {
+		row := &View{Direction: Row, Grow: 1, Shrink: 1, Basis: Int(0)}
+		for p := 0; p < panels; p++ {
+			panel := &View{Direction: Column, Grow: 1, Shrink: 1, Basis: Int(0), PaddingLeft: 4, PaddingTop: 4}
+			for l := 0; l < labels; l++ {
+				label := &View{Text: "label", Height: 16, MarginBottom: 2}
+				panel.AddChild(label)
+				leaves = append(leaves, label)
+			}
+			row.AddChild(panel)
+		}
+		root.AddChild(row)
+	}
+	root.layoutIfDirty()
+	return root, leaves
+}
+
+// BenchmarkLayoutResize lays out the whole tree, e.g. when the window is resized.
+func BenchmarkLayoutResize(b *testing.B) {
+	root, _ := newBenchmarkTree(10, 10, 10)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		root.SetHeight(1080 - i%2*100)
+		root.layoutIfDirty()
+	}
+}
+
+// BenchmarkLayoutLeafChange lays out the tree after a widget changed its
+// width, e.g. a health bar.
+func BenchmarkLayoutLeafChange(b *testing.B) {
+	root, leaves := newBenchmarkTree(10, 10, 10)
+	leaf := leaves[len(leaves)/2]
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		leaf.SetWidth(50 + i%2)
+		root.layoutIfDirty()
+	}
+}
+
+// BenchmarkLayoutClean checks the tree for dirty views when there is none.
+func BenchmarkLayoutClean(b *testing.B) {
+	root, _ := newBenchmarkTree(10, 10, 10)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		root.layoutIfDirty()
+	}
+}